@@ -0,0 +1,106 @@
+package slicer
+
+import (
+	"reflect"
+	"sync"
+)
+
+// Accessors give SlicePaginator typed access to the fields of T, keyed by
+// the JSON name of the field (the field name when it has no JSON tag). The
+// slicer-gen command generates them from a struct definition:
+//
+//	//go:generate go run github.com/godev90/slicer/cmd/slicer-gen -type=User
+//
+// Fields missing from the maps are read through reflection as before.
+type Accessors[T any] struct {
+	// Fields returns the value of a field of an item, as reflection would.
+	Fields map[string]func(T) any
+
+	// Compare orders two items by a field, returning a negative number when
+	// a sorts before b, a positive one when after and zero when equal.
+	Compare map[string]func(a, b T) int
+}
+
+// accessorRegistry maps the reflect.Type of T to its Accessors[T].
+var accessorRegistry sync.Map
+
+// RegisterAccessors makes SlicePaginator use a for slices of T and of *T.
+// It is meant to be called from init functions; registering T again
+// replaces its accessors.
+func RegisterAccessors[T any](a Accessors[T]) {
+	accessorRegistry.Store(reflect.TypeFor[T](), a)
+
+	ptr := Accessors[*T]{
+		Fields:  make(map[string]func(*T) any, len(a.Fields)),
+		Compare: make(map[string]func(a, b *T) int, len(a.Compare)),
+	}
+	for name, get := range a.Fields {
+		ptr.Fields[name] = func(item *T) any {
+			if item == nil {
+				return nil
+			}
+			return get(*item)
+		}
+	}
+	for name, compare := range a.Compare {
+		// nil items sort first
+		ptr.Compare[name] = func(a, b *T) int {
+			switch {
+			case a == nil && b == nil:
+				return 0
+			case a == nil:
+				return -1
+			case b == nil:
+				return 1
+			}
+			return compare(*a, *b)
+		}
+	}
+	accessorRegistry.Store(reflect.TypeFor[*T](), ptr)
+}
+
+// accessorsOf returns the accessors registered for T, or empty ones.
+func accessorsOf[T any]() Accessors[T] {
+	if a, ok := accessorRegistry.Load(reflect.TypeFor[T]()); ok {
+		return a.(Accessors[T])
+	}
+	return Accessors[T]{}
+}
+
+// field returns the value of the field matching column in item, through
+// the registered accessor or else reflection. The second result is false
+// when item has no such field.
+func (a Accessors[T]) field(item T, column string) (any, bool) {
+	if get, ok := a.Fields[column]; ok {
+		return get(item), true
+	}
+
+	v := reflect.ValueOf(item)
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil, false
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil, false
+	}
+	field := findFieldByColumn(v, column)
+	if !field.IsValid() {
+		return nil, false
+	}
+	return field.Interface(), true
+}
+
+// less reports whether x sorts before y by column, like compareSort.
+func (a Accessors[T]) less(x, y T, column string, desc bool) bool {
+	if compare, ok := a.Compare[column]; ok {
+		if desc {
+			return compare(x, y) > 0
+		}
+		return compare(x, y) < 0
+	}
+	vx, okx := a.field(x, column)
+	vy, oky := a.field(y, column)
+	return okx && oky && compareSort(vx, vy, desc)
+}
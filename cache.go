@@ -0,0 +1,172 @@
+package slicer
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"sync"
+	"time"
+)
+
+type (
+	// PageCache stores QueryPage results. Entries are grouped by table name
+	// so that writes to a table can drop everything read from it.
+	// Implementations must be safe for concurrent use.
+	PageCache interface {
+		Get(table, key string) (any, bool)
+		Set(table, key string, value any, ttl time.Duration)
+		Invalidate(table string)
+	}
+
+	// LRUCache is an in-memory PageCache holding at most a fixed number of
+	// entries, evicting the least recently used one first.
+	LRUCache struct {
+		mu       sync.Mutex
+		capacity int
+		order    *list.List
+		entries  map[string]*list.Element
+		tables   map[string]map[string]struct{}
+		now      func() time.Time
+	}
+
+	lruEntry struct {
+		table   string
+		key     string
+		value   any
+		expires time.Time
+	}
+)
+
+// NewLRUCache returns an LRUCache holding up to capacity entries. A
+// capacity below 1 is treated as 1.
+func NewLRUCache(capacity int) *LRUCache {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &LRUCache{
+		capacity: capacity,
+		order:    list.New(),
+		entries:  map[string]*list.Element{},
+		tables:   map[string]map[string]struct{}{},
+		now:      time.Now,
+	}
+}
+
+// Get returns the live value stored under table and key.
+func (c *LRUCache) Get(table, key string) (any, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[table+"\x00"+key]
+	if !ok {
+		return nil, false
+	}
+	entry := elem.Value.(*lruEntry)
+	if !entry.expires.IsZero() && !c.now().Before(entry.expires) {
+		c.remove(elem)
+		return nil, false
+	}
+	c.order.MoveToFront(elem)
+	return entry.value, true
+}
+
+// Set stores value under table and key for ttl. A ttl of zero or less keeps
+// the entry until it is evicted or invalidated.
+func (c *LRUCache) Set(table, key string, value any, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var expires time.Time
+	if ttl > 0 {
+		expires = c.now().Add(ttl)
+	}
+
+	id := table + "\x00" + key
+	if elem, ok := c.entries[id]; ok {
+		entry := elem.Value.(*lruEntry)
+		entry.value, entry.expires = value, expires
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	c.entries[id] = c.order.PushFront(&lruEntry{table: table, key: key, value: value, expires: expires})
+	if c.tables[table] == nil {
+		c.tables[table] = map[string]struct{}{}
+	}
+	c.tables[table][key] = struct{}{}
+
+	for c.order.Len() > c.capacity {
+		c.remove(c.order.Back())
+	}
+}
+
+// Invalidate drops every entry stored for table.
+func (c *LRUCache) Invalidate(table string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key := range c.tables[table] {
+		c.remove(c.entries[table+"\x00"+key])
+	}
+}
+
+// Len returns the number of entries, including expired ones not yet
+// evicted.
+func (c *LRUCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+func (c *LRUCache) remove(elem *list.Element) {
+	entry := c.order.Remove(elem).(*lruEntry)
+	delete(c.entries, entry.table+"\x00"+entry.key)
+	delete(c.tables[entry.table], entry.key)
+	if len(c.tables[entry.table]) == 0 {
+		delete(c.tables, entry.table)
+	}
+}
+
+// pageCacheKey identifies a page: the caller's scope, the canonical
+// options and the allowed fields they were resolved against.
+func pageCacheKey(scope string, opts QueryOptions, allowed map[string]string) string {
+	return "page:" + scopeHash(scope) + ":" + opts.Hash() + ":" + allowedHash(allowed)
+}
+
+// countCacheKey identifies a total. Only the options narrowing the rows
+// count, so pages of the same query share one total.
+func countCacheKey(scope string, opts QueryOptions, allowed map[string]string) string {
+	opts.Page, opts.Limit, opts.Offset = 1, 0, 0
+	opts.Sort, opts.Select, opts.Cursor = nil, nil, nil
+	return "count:" + scopeHash(scope) + ":" + opts.Hash() + ":" + allowedHash(allowed)
+}
+
+// cacheScope returns the cache scope of ctx, or false when caching is
+// off because Config.CacheScope is unset.
+func (c Config) cacheScope(ctx context.Context) (string, bool) {
+	if c.Cache == nil || c.CacheScope == nil {
+		return "", false
+	}
+	return c.CacheScope(ctx), true
+}
+
+func scopeHash(scope string) string {
+	h := sha256.Sum256([]byte(scope))
+	return hex.EncodeToString(h[:8])
+}
+
+func allowedHash(allowed map[string]string) string {
+	keys := make([]string, 0, len(allowed))
+	for k := range allowed {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	h := sha256.New()
+	for _, k := range keys {
+		h.Write([]byte(k + "=" + allowed[k] + "\x00"))
+	}
+	return hex.EncodeToString(h.Sum(nil)[:8])
+}
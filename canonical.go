@@ -0,0 +1,210 @@
+package slicer
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Canonical returns the normal form of the options: queries that select the
+// same rows in the same order have equal normal forms. It drops duplicate
+// sort fields (only the first one orders), sorts and deduplicates fields and
+// values whose order does not matter (search fields, search_and entries,
+// select, comparisons, in/nin lists), drops empty search keywords, defaults
+// the page to 1 and simplifies the filter expression. The receiver is not
+// modified.
+func (o QueryOptions) Canonical() QueryOptions {
+	c := QueryOptions{
+		Page:      o.Page,
+		Limit:     o.Limit,
+		Offset:    o.Offset,
+		Filters:   map[string]string{},
+		Separator: o.Separator,
+		Count:     o.Count,
+	}
+	if c.Separator == packageSeparator() {
+		c.Separator = ""
+	}
+	sep := c.separator()
+
+	if c.Page < 1 {
+		c.Page = 1
+	}
+	if c.Offset == 0 && c.Limit > 0 {
+		c.Offset = (c.Page - 1) * c.Limit
+	}
+
+	seen := map[string]bool{}
+	for _, s := range o.Sort {
+		if !seen[s.Field] {
+			seen[s.Field] = true
+			c.Sort = append(c.Sort, s)
+		}
+	}
+
+	if o.Search != nil && o.Search.Keyword != "" {
+		c.Search = &SearchQuery{Fields: sortedSet(o.Search.Fields), Keyword: o.Search.Keyword}
+	}
+
+	if o.SearchAnd != nil {
+		fields := make([]*SearchField, 0, len(o.SearchAnd.Fields))
+		for _, f := range o.SearchAnd.Fields {
+			if f != nil && f.Keyword != "" {
+				fields = append(fields, &SearchField{Field: f.Field, Keyword: f.Keyword})
+			}
+		}
+		sort.Slice(fields, func(i, j int) bool {
+			if fields[i].Field != fields[j].Field {
+				return fields[i].Field < fields[j].Field
+			}
+			return fields[i].Keyword < fields[j].Keyword
+		})
+		fields = slices.CompactFunc(fields, func(a, b *SearchField) bool { return *a == *b })
+		if len(fields) > 0 {
+			c.SearchAnd = &SearchQueryAnd{Fields: fields}
+		}
+	}
+
+	for key, val := range o.Filters {
+		c.Filters[key] = val
+	}
+
+	c.Select = sortedSet(o.Select)
+	if len(o.GroupBy) > 0 {
+		seen := map[string]bool{}
+		for _, field := range o.GroupBy {
+			if !seen[field] {
+				seen[field] = true
+				c.GroupBy = append(c.GroupBy, field)
+			}
+		}
+	}
+
+	for _, cmp := range o.Comparisons {
+		c.Comparisons = append(c.Comparisons, canonicalComparison(cmp, sep))
+	}
+	sort.Slice(c.Comparisons, func(i, j int) bool {
+		a, b := c.Comparisons[i], c.Comparisons[j]
+		if a.Field != b.Field {
+			return a.Field < b.Field
+		}
+		if a.Op != b.Op {
+			return a.Op < b.Op
+		}
+		return a.Value < b.Value
+	})
+	c.Comparisons = slices.Compact(c.Comparisons)
+
+	if o.Cursor != nil {
+		cursor := *o.Cursor
+		c.Cursor = &cursor
+	}
+	c.Filter = canonicalExpr(o.Filter, sep)
+
+	return c
+}
+
+// Hash returns a stable hex-encoded SHA-256 of the canonical options, fit for
+// use as a cache key. Equal queries hash equally regardless of map order or
+// field order. The key only covers the options: callers caching QueryPage or
+// SlicePage results must combine it with the paginator or data set identity.
+func (o QueryOptions) Hash() string {
+	c := o.Canonical()
+
+	h := sha256.New()
+	h.Write([]byte(c.Encode()))
+	// Encode derives the offset from page and limit; explicit offsets
+	// (e.g. an OData $skip) must still produce different keys
+	h.Write([]byte("\x00offset=" + strconv.Itoa(c.Offset)))
+	if c.Separator != "" {
+		h.Write([]byte("\x00separator=" + c.Separator))
+	}
+	if c.Count != "" {
+		h.Write([]byte("\x00count=" + string(c.Count)))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// sortedSet returns the sorted, deduplicated values, or nil when empty.
+func sortedSet(values []string) []string {
+	if len(values) == 0 {
+		return nil
+	}
+	set := slices.Clone(values)
+	sort.Strings(set)
+	return slices.Compact(set)
+}
+
+// canonicalComparison sorts and deduplicates the value list of in and nin
+// comparisons and normalises isnull/notnull flags.
+func canonicalComparison(cmp ComparisonFilter, sep string) ComparisonFilter {
+	switch cmp.Op {
+	case IN, NIN:
+		cmp.Value = strings.Join(sortedSet(strings.Split(cmp.Value, sep)), sep)
+	case ISNULL, NOTNULL:
+		// `x[notnull]=false` selects the same rows as `x[isnull]`
+		flag, ok := nullFlag(cmp.Value)
+		if !ok {
+			// malformed flags stay apart from valid ones
+			break
+		}
+		if !flag {
+			if cmp.Op == ISNULL {
+				cmp.Op = NOTNULL
+			} else {
+				cmp.Op = ISNULL
+			}
+		}
+		cmp.Value = ""
+	}
+	return cmp
+}
+
+// canonicalExpr returns a simplified copy of e: nested groups of the same
+// operator are flattened, children are sorted and deduplicated, single-child
+// and/or groups are unwrapped and double negations removed.
+func canonicalExpr(e *FilterExpr, sep string) *FilterExpr {
+	if e == nil {
+		return nil
+	}
+	if e.Comparison != nil {
+		cmp := canonicalComparison(*e.Comparison, sep)
+		return &FilterExpr{Comparison: &cmp}
+	}
+
+	if e.Op == LogicNot {
+		if len(e.Children) != 1 {
+			return &FilterExpr{Op: e.Op}
+		}
+		child := canonicalExpr(e.Children[0], sep)
+		if child.Op == LogicNot && len(child.Children) == 1 {
+			return child.Children[0]
+		}
+		return Not(child)
+	}
+
+	var children []*FilterExpr
+	for _, child := range e.Children {
+		child = canonicalExpr(child, sep)
+		if child.Op == e.Op && child.Comparison == nil {
+			children = append(children, child.Children...)
+		} else {
+			children = append(children, child)
+		}
+	}
+
+	keys := make(map[*FilterExpr]string, len(children))
+	for _, child := range children {
+		keys[child] = child.format(sep)
+	}
+	sort.SliceStable(children, func(i, j int) bool { return keys[children[i]] < keys[children[j]] })
+	children = slices.CompactFunc(children, func(a, b *FilterExpr) bool { return keys[a] == keys[b] })
+
+	if len(children) == 1 {
+		return children[0]
+	}
+	return &FilterExpr{Op: e.Op, Children: children}
+}
@@ -0,0 +1,272 @@
+// Command slicer-gen generates slicer.Accessors for struct types, so that
+// SlicePaginator reads and compares their fields without reflection.
+//
+// Usage:
+//
+//	slicer-gen -type=User,Order [-output=file.go] [dir]
+//
+// It reads the Go files of the package in dir (the current directory by
+// default) and writes a file registering the accessors of every listed
+// type from an init function, usually from a go:generate directive:
+//
+//	//go:generate go run github.com/godev90/slicer/cmd/slicer-gen -type=User
+//
+// Fields are keyed by their JSON name, or their name when untagged. Fields
+// that are embedded, unexported or tagged json:"-" are left to reflection.
+// Compare functions are generated for the types SlicePaginator sorts:
+// strings, int, int64, float32, float64, time.Time and the typedef
+// Integer, Float, Date and Datetime.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+const typedefPath = "github.com/godev90/validator/typedef"
+
+func main() {
+	typeNames := flag.String("type", "", "comma-separated list of struct types")
+	output := flag.String("output", "", "output file; defaults to <type>_slicer.go")
+	flag.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: slicer-gen -type=T[,T...] [-output=file.go] [dir]")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if *typeNames == "" || flag.NArg() > 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	dir := "."
+	if flag.NArg() == 1 {
+		dir = flag.Arg(0)
+	}
+	types := strings.Split(*typeNames, ",")
+
+	src, err := generate(dir, types)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "slicer-gen:", err)
+		os.Exit(1)
+	}
+
+	name := *output
+	if name == "" {
+		name = filepath.Join(dir, strings.ToLower(types[0])+"_slicer.go")
+	}
+	if err := os.WriteFile(name, src, 0o644); err != nil {
+		fmt.Fprintln(os.Stderr, "slicer-gen:", err)
+		os.Exit(1)
+	}
+}
+
+// field is a struct field with a generated accessor.
+type field struct {
+	key     string // JSON name
+	name    string // Go name
+	compare string // compare expression template, empty when not sortable
+}
+
+// generate returns the formatted source registering the accessors of types,
+// which are declared in the package in dir.
+func generate(dir string, types []string) ([]byte, error) {
+	fset := token.NewFileSet()
+	paths, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		pkg     string
+		structs = map[string]*ast.StructType{}
+		files   = map[string]*ast.File{}
+	)
+	for _, path := range paths {
+		if strings.HasSuffix(path, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(fset, path, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, err
+		}
+		pkg = file.Name.Name
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				ts := spec.(*ast.TypeSpec)
+				if st, ok := ts.Type.(*ast.StructType); ok && ts.TypeParams == nil {
+					structs[ts.Name.Name], files[ts.Name.Name] = st, file
+				}
+			}
+		}
+	}
+	if pkg == "" {
+		return nil, fmt.Errorf("no Go files in %s", dir)
+	}
+
+	var (
+		body    bytes.Buffer
+		needCmp bool
+	)
+	for _, name := range types {
+		name = strings.TrimSpace(name)
+		st, ok := structs[name]
+		if !ok {
+			return nil, fmt.Errorf("struct type %s not found in %s", name, dir)
+		}
+		fields := structFields(st, imports(files[name]))
+		if len(fields) == 0 {
+			return nil, fmt.Errorf("struct type %s has no accessible fields", name)
+		}
+
+		fmt.Fprintf(&body, "\tslicer.RegisterAccessors(slicer.Accessors[%s]{\n", name)
+		fmt.Fprintf(&body, "\t\tFields: map[string]func(%s) any{\n", name)
+		for _, f := range fields {
+			fmt.Fprintf(&body, "\t\t\t%s: func(v %s) any { return v.%s },\n", strconv.Quote(f.key), name, f.name)
+		}
+		fmt.Fprintf(&body, "\t\t},\n")
+		fmt.Fprintf(&body, "\t\tCompare: map[string]func(a, b %s) int{\n", name)
+		for _, f := range fields {
+			if f.compare == "" {
+				continue
+			}
+			expr := strings.NewReplacer("$a", "a."+f.name, "$b", "b."+f.name).Replace(f.compare)
+			needCmp = needCmp || strings.HasPrefix(expr, "cmp.")
+			fmt.Fprintf(&body, "\t\t\t%s: func(a, b %s) int { return %s },\n", strconv.Quote(f.key), name, expr)
+		}
+		fmt.Fprintf(&body, "\t\t},\n\t})\n")
+	}
+
+	var src bytes.Buffer
+	fmt.Fprintf(&src, "// Code generated by slicer-gen. DO NOT EDIT.\n\npackage %s\n\nimport (\n", pkg)
+	if needCmp {
+		fmt.Fprintf(&src, "\t\"cmp\"\n\n")
+	}
+	fmt.Fprintf(&src, "\t\"github.com/godev90/slicer\"\n)\n\nfunc init() {\n%s}\n", body.String())
+	return format.Source(src.Bytes())
+}
+
+// imports maps the names under which file imports packages to their paths.
+func imports(file *ast.File) map[string]string {
+	names := map[string]string{}
+	for _, spec := range file.Imports {
+		path, _ := strconv.Unquote(spec.Path.Value)
+		name := path[strings.LastIndex(path, "/")+1:]
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+		names[name] = path
+	}
+	return names
+}
+
+// structFields returns the fields of st that get accessors, in declaration
+// order. Keys that reflection resolves to an earlier field, by its JSON name
+// or its Go name in any case, are skipped.
+func structFields(st *ast.StructType, imports map[string]string) []field {
+	var (
+		fields []field
+		seen   = map[string]bool{}
+		names  []string
+	)
+	shadowed := func(key string) bool {
+		if seen[key] {
+			return true
+		}
+		for _, name := range names {
+			if strings.EqualFold(name, key) {
+				return true
+			}
+		}
+		return false
+	}
+	for _, f := range st.Fields.List {
+		tag := ""
+		if f.Tag != nil {
+			raw, _ := strconv.Unquote(f.Tag.Value)
+			tag = reflect.StructTag(raw).Get("json")
+		}
+		if len(f.Names) == 0 || tag == "-" {
+			// still matched by name through reflection
+			names = append(names, fieldNames(f)...)
+			continue
+		}
+		for _, ident := range f.Names {
+			key := ident.Name
+			if name := strings.Split(tag, ",")[0]; name != "" {
+				key = name
+			}
+			if ident.IsExported() && !shadowed(key) {
+				fields = append(fields, field{key: key, name: ident.Name, compare: compareExpr(f.Type, imports)})
+			}
+			seen[key] = true
+			names = append(names, ident.Name)
+		}
+	}
+	return fields
+}
+
+// fieldNames returns the names of f, which for an embedded field is the
+// name of its type.
+func fieldNames(f *ast.Field) []string {
+	if len(f.Names) > 0 {
+		names := make([]string, len(f.Names))
+		for i, ident := range f.Names {
+			names[i] = ident.Name
+		}
+		return names
+	}
+	typ := f.Type
+	if star, ok := typ.(*ast.StarExpr); ok {
+		typ = star.X
+	}
+	switch t := typ.(type) {
+	case *ast.Ident:
+		return []string{t.Name}
+	case *ast.SelectorExpr:
+		return []string{t.Sel.Name}
+	}
+	return nil
+}
+
+// compareExpr returns the compare expression for a field of type expr, with
+// $a and $b standing for the two field values, or "" when SlicePaginator
+// does not sort the type.
+func compareExpr(expr ast.Expr, imports map[string]string) string {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		switch t.Name {
+		case "string", "int", "int64", "float32", "float64":
+			return "cmp.Compare($a, $b)"
+		}
+	case *ast.SelectorExpr:
+		pkg, ok := t.X.(*ast.Ident)
+		if !ok {
+			return ""
+		}
+		switch path := imports[pkg.Name]; {
+		case path == "time" && t.Sel.Name == "Time":
+			return "$a.Compare($b)"
+		case path == typedefPath && t.Sel.Name == "Integer":
+			return "cmp.Compare($a.Int64(), $b.Int64())"
+		case path == typedefPath && t.Sel.Name == "Float":
+			return "cmp.Compare($a.Float64(), $b.Float64())"
+		case path == typedefPath && (t.Sel.Name == "Date" || t.Sel.Name == "Datetime"):
+			return "$a.Time().Compare($b.Time())"
+		}
+	}
+	return ""
+}
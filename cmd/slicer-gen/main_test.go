@@ -0,0 +1,101 @@
+package main
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGenerate(t *testing.T) {
+	dir := t.TempDir()
+	src := `package models
+
+import (
+	"time"
+
+	td "github.com/godev90/validator/typedef"
+)
+
+type Base struct{ ID int }
+
+type User struct {
+	Base
+	Name    string    ` + "`json:\"name\"`" + `
+	Nick    string    ` + "`json:\"Name\"`" + `
+	Hidden  string    ` + "`json:\"-\"`" + `
+	Score   td.Float  ` + "`json:\"score,omitempty\"`" + `
+	Created time.Time ` + "`json:\"created_at\"`" + `
+	Tags    []string  ` + "`json:\"tags\"`" + `
+	secret  string
+	Rank    int64
+}
+`
+	if err := os.WriteFile(filepath.Join(dir, "user.go"), []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := generate(dir, []string{"User"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := string(out)
+
+	for _, want := range []string{
+		"// Code generated by slicer-gen. DO NOT EDIT.",
+		"package models",
+		`"name":       func(v User) any { return v.Name },`,
+		`"score":      func(v User) any { return v.Score },`,
+		`"tags":       func(v User) any { return v.Tags },`,
+		`"Rank":       func(v User) any { return v.Rank },`,
+		`"score":      func(a, b User) int { return cmp.Compare(a.Score.Float64(), b.Score.Float64()) },`,
+		`"created_at": func(a, b User) int { return a.Created.Compare(b.Created) },`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Expected output to contain %s\n%s", want, got)
+		}
+	}
+	// reflection resolves these to other fields or cannot read them
+	for _, unwanted := range []string{"v.Nick", "v.Hidden", "v.secret", "v.Base", `"tags":       func(a, b`} {
+		if strings.Contains(got, unwanted) {
+			t.Errorf("Unexpected %s in output\n%s", unwanted, got)
+		}
+	}
+
+	typeCheck(t, map[string]string{"user.go": src, "user_slicer.go": got})
+
+	if _, err := generate(dir, []string{"Order"}); err == nil {
+		t.Error("Expected an error for an unknown type")
+	}
+}
+
+// typeCheck type-checks files as one package. They are placed in the
+// working directory, inside this module, so that their imports resolve
+// against its dependencies.
+func typeCheck(t *testing.T, files map[string]string) {
+	t.Helper()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fset := token.NewFileSet()
+	var parsed []*ast.File
+	for name, src := range files {
+		f, err := parser.ParseFile(fset, filepath.Join(wd, name), src, 0)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		parsed = append(parsed, f)
+	}
+
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	if _, err := conf.Check("models", fset, parsed, nil); err != nil {
+		t.Errorf("Generated code does not type-check: %v", err)
+	}
+}
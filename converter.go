@@ -59,6 +59,14 @@ func (q QueryOptions) ToProto() *slicerpb.QueryOptions {
 		}
 	}
 
+	var cursor *slicerpb.CursorQuery
+	if q.Cursor != nil {
+		cursor = &slicerpb.CursorQuery{
+			After:  q.Cursor.After,
+			Before: q.Cursor.Before,
+		}
+	}
+
 	if q.Limit == 0 {
 		q.Limit = 10
 	}
@@ -77,6 +85,10 @@ func (q QueryOptions) ToProto() *slicerpb.QueryOptions {
 		Filters:     q.Filters,
 		GroupBy:     q.GroupBy,
 		Comparisons: comparisons,
+		Cursor:      cursor,
+		Filter:      filterToProto(q.Filter),
+		Separator:   q.Separator,
+		Count:       string(q.Count),
 	}
 }
 
@@ -140,6 +152,14 @@ func QueryFromProto(pb *slicerpb.QueryOptions) QueryOptions {
 		}
 	}
 
+	var cursor *CursorQuery
+	if pb.Cursor != nil {
+		cursor = &CursorQuery{
+			After:  pb.Cursor.After,
+			Before: pb.Cursor.Before,
+		}
+	}
+
 	return QueryOptions{
 		Page:        int(pb.Page),
 		Limit:       int(pb.Limit),
@@ -151,7 +171,80 @@ func QueryFromProto(pb *slicerpb.QueryOptions) QueryOptions {
 		GroupBy:     pb.GroupBy,
 		Filters:     pb.Filters,
 		Comparisons: comparisons,
+		Cursor:      cursor,
+		Filter:      filterFromProto(pb.Filter),
+		Separator:   pb.Separator,
+		Count:       CountStrategy(pb.Count),
+	}
+}
+
+// linksToProto converts PageLinks into its protobuf message.
+func linksToProto(links *PageLinks) *slicerpb.PageLinks {
+	if links == nil {
+		return nil
+	}
+	return &slicerpb.PageLinks{
+		Self:  links.Self,
+		Next:  links.Next,
+		Prev:  links.Prev,
+		First: links.First,
+		Last:  links.Last,
+	}
+}
+
+// linksFromProto converts a protobuf PageLinks message back into PageLinks.
+func linksFromProto(pb *slicerpb.PageLinks) *PageLinks {
+	if pb == nil {
+		return nil
+	}
+	return &PageLinks{
+		Self:  pb.Self,
+		Next:  pb.Next,
+		Prev:  pb.Prev,
+		First: pb.First,
+		Last:  pb.Last,
+	}
+}
+
+// filterToProto converts a FilterExpr tree into its recursive protobuf
+// message.
+func filterToProto(e *FilterExpr) *slicerpb.FilterExpr {
+	if e == nil {
+		return nil
+	}
+
+	msg := &slicerpb.FilterExpr{Op: string(e.Op)}
+	if e.Comparison != nil {
+		msg.Comparison = &slicerpb.ComparisonFilter{
+			Field: e.Comparison.Field,
+			Op:    string(e.Comparison.Op),
+			Value: e.Comparison.Value,
+		}
+	}
+	for _, child := range e.Children {
+		msg.Children = append(msg.Children, filterToProto(child))
+	}
+	return msg
+}
+
+// filterFromProto converts a protobuf FilterExpr back into the local tree.
+func filterFromProto(msg *slicerpb.FilterExpr) *FilterExpr {
+	if msg == nil {
+		return nil
+	}
+
+	e := &FilterExpr{Op: LogicOp(msg.Op)}
+	if msg.Comparison != nil {
+		e.Comparison = &ComparisonFilter{
+			Field: msg.Comparison.Field,
+			Op:    ComparisonOp(msg.Comparison.Op),
+			Value: msg.Comparison.Value,
+		}
+	}
+	for _, child := range msg.Children {
+		e.Children = append(e.Children, filterFromProto(child))
 	}
+	return e
 }
 
 // ToProto serializes PageData.Items into JSON and returns a protobuf
@@ -178,10 +271,21 @@ func (data PageData) ToProto() (*slicerpb.PageData, error) {
 	}
 
 	return &slicerpb.PageData{
-		Page:  page,
-		Limit: limit,
-		Total: data.Total,
-		Items: compressed,
+		Page:          page,
+		Limit:         limit,
+		Total:         data.Total,
+		Items:         compressed,
+		NextCursor:    data.NextCursor,
+		PrevCursor:    data.PrevCursor,
+		CountStrategy: string(data.CountStrategy),
+		TotalCapped:   data.TotalCapped,
+		HasMore:       data.HasMore,
+		TotalPages:    data.TotalPages,
+		HasNext:       data.HasNext,
+		HasPrev:       data.HasPrev,
+		From:          data.From,
+		To:            data.To,
+		Links:         linksToProto(data.Links),
 	}, nil
 }
 
@@ -223,10 +327,21 @@ func PageFromProto(protoData *slicerpb.PageData, destSchema any) (*PageData, err
 	}
 
 	return &PageData{
-		Page:  int(page),
-		Limit: int(limit),
-		Total: protoData.Total,
-		Items: destSchema,
+		Page:          int(page),
+		Limit:         int(limit),
+		Total:         protoData.Total,
+		Items:         destSchema,
+		NextCursor:    protoData.NextCursor,
+		PrevCursor:    protoData.PrevCursor,
+		CountStrategy: CountStrategy(protoData.CountStrategy),
+		TotalCapped:   protoData.TotalCapped,
+		HasMore:       protoData.HasMore,
+		TotalPages:    protoData.TotalPages,
+		HasNext:       protoData.HasNext,
+		HasPrev:       protoData.HasPrev,
+		From:          protoData.From,
+		To:            protoData.To,
+		Links:         linksFromProto(protoData.Links),
 	}, nil
 }
 
@@ -259,10 +374,21 @@ func (data PageData) ToProtoBuf() (*slicerpb.PageDataBuf, error) {
 	}
 
 	return &slicerpb.PageDataBuf{
-		Page:  page,
-		Limit: limit,
-		Total: data.Total,
-		Items: anyVal,
+		Page:          page,
+		Limit:         limit,
+		Total:         data.Total,
+		Items:         anyVal,
+		NextCursor:    data.NextCursor,
+		PrevCursor:    data.PrevCursor,
+		CountStrategy: string(data.CountStrategy),
+		TotalCapped:   data.TotalCapped,
+		HasMore:       data.HasMore,
+		TotalPages:    data.TotalPages,
+		HasNext:       data.HasNext,
+		HasPrev:       data.HasPrev,
+		From:          data.From,
+		To:            data.To,
+		Links:         linksToProto(data.Links),
 	}, nil
 }
 
@@ -277,10 +403,21 @@ func PageFromProtoBuf(protoData *slicerpb.PageDataBuf, destSchema any) (*PageDat
 
 	if protoData.Items == nil {
 		return &PageData{
-			Page:  int(protoData.Page),
-			Limit: int(protoData.Limit),
-			Total: protoData.Total,
-			Items: []string{},
+			Page:          int(protoData.Page),
+			Limit:         int(protoData.Limit),
+			Total:         protoData.Total,
+			Items:         []string{},
+			NextCursor:    protoData.NextCursor,
+			PrevCursor:    protoData.PrevCursor,
+			CountStrategy: CountStrategy(protoData.CountStrategy),
+			TotalCapped:   protoData.TotalCapped,
+			HasMore:       protoData.HasMore,
+			TotalPages:    protoData.TotalPages,
+			HasNext:       protoData.HasNext,
+			HasPrev:       protoData.HasPrev,
+			From:          protoData.From,
+			To:            protoData.To,
+			Links:         linksFromProto(protoData.Links),
 		}, nil
 	}
 
@@ -305,10 +442,21 @@ func PageFromProtoBuf(protoData *slicerpb.PageDataBuf, destSchema any) (*PageDat
 	}
 
 	return &PageData{
-		Page:  int(page),
-		Limit: int(limit),
-		Total: protoData.Total,
-		Items: destSchema,
+		Page:          int(page),
+		Limit:         int(limit),
+		Total:         protoData.Total,
+		Items:         destSchema,
+		NextCursor:    protoData.NextCursor,
+		PrevCursor:    protoData.PrevCursor,
+		CountStrategy: CountStrategy(protoData.CountStrategy),
+		TotalCapped:   protoData.TotalCapped,
+		HasMore:       protoData.HasMore,
+		TotalPages:    protoData.TotalPages,
+		HasNext:       protoData.HasNext,
+		HasPrev:       protoData.HasPrev,
+		From:          protoData.From,
+		To:            protoData.To,
+		Links:         linksFromProto(protoData.Links),
 	}, nil
 }
 
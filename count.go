@@ -0,0 +1,183 @@
+package slicer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/godev90/orm"
+)
+
+var (
+	ErrNoPlanRows error = errors.New("slicer: no plan rows in explain output")
+)
+
+// CountStrategy selects how QueryPage computes PageData.Total. Counting
+// every matching row is exact but slow on large tables; the other
+// strategies trade precision for speed.
+type CountStrategy string
+
+const (
+	// CountExact runs SELECT count(*) over the query. It is the default.
+	CountExact CountStrategy = "exact"
+
+	// CountCapped counts at most Config.CountCap rows, as in
+	// SELECT count(*) FROM (... LIMIT cap+1). Larger results report the
+	// cap with PageData.TotalCapped set, rendered as "1000+".
+	CountCapped CountStrategy = "capped"
+
+	// CountEstimated asks the Postgres planner for its row estimate with
+	// EXPLAIN (FORMAT JSON). It needs an adapter implementing SQLQuerier
+	// and falls back to CountCapped otherwise. As the estimate may be off,
+	// PageData.HasMore comes from fetching one row more than the limit.
+	CountEstimated CountStrategy = "estimated"
+
+	// CountNone skips counting: Total is -1 and PageData.HasMore is
+	// derived by fetching one row more than the limit.
+	CountNone CountStrategy = "none"
+)
+
+type (
+	// SQLQuerier is implemented by query adapters that expose the SQL of
+	// the current query and run raw statements. Capped counts wrap the
+	// query in SELECT count(*) FROM (<query> LIMIT n) and estimates run
+	// EXPLAIN (FORMAT JSON) over it. Adapters without it are counted by
+	// scanning a constant column of up to n rows, and estimated totals
+	// fall back to capped ones.
+	SQLQuerier interface {
+		// ToSQL returns the statement the adapter would run for Scan.
+		ToSQL() (query string, args []any)
+
+		// RawScan runs query and scans its single column into dest.
+		RawScan(dest any, query string, args ...any) error
+	}
+
+	// countResult is a computed total, as stored in the count cache.
+	countResult struct {
+		total    int64
+		strategy CountStrategy
+		capped   bool
+	}
+)
+
+const (
+	// defaultCountCap is the cap of CountCapped when Config.CountCap is
+	// unset.
+	defaultCountCap = 1000
+
+	// defaultCountTimeout bounds the count when Config.CountTimeout is
+	// unset.
+	defaultCountTimeout = 3 * time.Second
+)
+
+// PlanRows returns the estimated row count of the top plan node in the
+// output of a Postgres EXPLAIN (FORMAT JSON) statement.
+func PlanRows(explain []byte) (int64, error) {
+	var plans []struct {
+		Plan struct {
+			Rows *float64 `json:"Plan Rows"`
+		} `json:"Plan"`
+	}
+	if err := json.Unmarshal(explain, &plans); err != nil {
+		return 0, fmt.Errorf("slicer: invalid explain output: %w", err)
+	}
+	if len(plans) == 0 || plans[0].Plan.Rows == nil {
+		return 0, ErrNoPlanRows
+	}
+	return int64(*plans[0].Plan.Rows), nil
+}
+
+// TotalString renders Total for display: "1000+" for capped totals and an
+// empty string when the total is unknown.
+func (p PageData) TotalString() string {
+	switch {
+	case p.Total < 0:
+		return ""
+	case p.TotalCapped:
+		return strconv.FormatInt(p.Total, 10) + "+"
+	}
+	return strconv.FormatInt(p.Total, 10)
+}
+
+// countStrategy returns the strategy for opts: its own, the configured
+// default, or CountExact.
+func (c Config) countStrategy(opts QueryOptions) CountStrategy {
+	switch {
+	case opts.Count != "":
+		return opts.Count
+	case c.CountStrategy != "":
+		return c.CountStrategy
+	}
+	return CountExact
+}
+
+// countContext returns the context the count runs under: ctx bounded by
+// CountTimeout.
+func (c Config) countContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	switch {
+	case c.CountTimeout < 0:
+		return context.WithCancel(ctx)
+	case c.CountTimeout == 0:
+		return context.WithTimeout(ctx, defaultCountTimeout)
+	}
+	return context.WithTimeout(ctx, c.CountTimeout)
+}
+
+func (c Config) countCap() int {
+	if c.CountCap > 0 {
+		return c.CountCap
+	}
+	return defaultCountCap
+}
+
+// countRows computes the total of db with the given strategy. The strategy
+// of the result is the one actually used.
+func countRows(ctx context.Context, db orm.QueryAdapter, strategy CountStrategy, limit int, postgres bool) (countResult, error) {
+	query := db.Clone().WithContext(ctx)
+	querier, raw := query.(SQLQuerier)
+
+	if strategy == CountEstimated {
+		// only the Postgres planner gives a cheap estimate
+		if !raw || !postgres {
+			strategy = CountCapped
+		} else {
+			var plan string
+			sql, args := querier.ToSQL()
+			if err := querier.RawScan(&plan, "EXPLAIN (FORMAT JSON) "+sql, args...); err != nil {
+				return countResult{strategy: CountEstimated}, err
+			}
+			total, err := PlanRows([]byte(plan))
+			return countResult{total: total, strategy: CountEstimated}, err
+		}
+	}
+
+	switch strategy {
+	case CountNone:
+		return countResult{total: -1, strategy: CountNone}, nil
+
+	case CountCapped:
+		var (
+			total int64
+			err   error
+		)
+		if raw {
+			sql, args := querier.ToSQL()
+			err = querier.RawScan(&total, fmt.Sprintf("SELECT count(*) FROM (%s LIMIT %d) AS capped", sql, limit+1), args...)
+		} else {
+			var rows []int
+			err = query.Select([]string{"1"}).Limit(limit + 1).Scan(&rows)
+			total = int64(len(rows))
+		}
+		if total > int64(limit) {
+			return countResult{total: int64(limit), strategy: CountCapped, capped: true}, err
+		}
+		return countResult{total: total, strategy: CountCapped}, err
+	}
+
+	var total int64
+	err := query.Count(&total)
+	return countResult{total: total, strategy: CountExact}, err
+}
@@ -0,0 +1,71 @@
+package slicer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/godev90/orm"
+)
+
+// sqlAdapter records the raw statements countRows runs.
+type sqlAdapter struct {
+	orm.QueryAdapter
+	statements []string
+	result     any
+}
+
+func (a *sqlAdapter) Clone() orm.QueryAdapter                      { return a }
+func (a *sqlAdapter) WithContext(context.Context) orm.QueryAdapter { return a }
+
+func (a *sqlAdapter) ToSQL() (string, []any) {
+	return "SELECT id FROM orders WHERE status = $1", []any{"open"}
+}
+
+func (a *sqlAdapter) RawScan(dest any, query string, args ...any) error {
+	a.statements = append(a.statements, query)
+	switch dest := dest.(type) {
+	case *int64:
+		*dest = a.result.(int64)
+	case *string:
+		*dest = a.result.(string)
+	}
+	return nil
+}
+
+func TestCountRowsSQL(t *testing.T) {
+	t.Run("Capped counts in a subquery", func(t *testing.T) {
+		db := &sqlAdapter{result: int64(11)}
+		count, err := countRows(context.Background(), db, CountCapped, 10, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if count.total != 10 || !count.capped {
+			t.Errorf("Expected a capped total of 10, got %+v", count)
+		}
+		if want := "SELECT count(*) FROM (SELECT id FROM orders WHERE status = $1 LIMIT 11) AS capped"; len(db.statements) != 1 || db.statements[0] != want {
+			t.Errorf("Expected %q, got %q", want, db.statements)
+		}
+	})
+
+	t.Run("Estimated explains the query", func(t *testing.T) {
+		db := &sqlAdapter{result: `[{"Plan": {"Node Type": "Seq Scan", "Plan Rows": 52000}}]`}
+		count, err := countRows(context.Background(), db, CountEstimated, 10, true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if count.total != 52000 || count.strategy != CountEstimated {
+			t.Errorf("Expected an estimate of 52000, got %+v", count)
+		}
+		if want := "EXPLAIN (FORMAT JSON) SELECT id FROM orders WHERE status = $1"; len(db.statements) != 1 || db.statements[0] != want {
+			t.Errorf("Expected %q, got %q", want, db.statements)
+		}
+	})
+
+	t.Run("Estimates need Postgres", func(t *testing.T) {
+		db := &sqlAdapter{result: int64(3)}
+		count, _ := countRows(context.Background(), db, CountEstimated, 10, false)
+		if count.strategy != CountCapped || count.total != 3 {
+			t.Errorf("Expected a capped count of 3, got %+v", count)
+		}
+	})
+}
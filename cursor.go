@@ -0,0 +1,317 @@
+package slicer
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql/driver"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/godev90/validator/typedef"
+)
+
+var (
+	ErrInvalidCursor  error = errors.New("slicer: invalid cursor")
+	ErrNoCursorSecret error = errors.New("slicer: no cursor secret, set Config.CursorSecret or call SetCursorSecret")
+
+	// ErrNullableCursorField is returned for cursor pages sorted by a field
+	// that can hold NULL: a NULL keyset value has no position to seek past.
+	ErrNullableCursorField error = errors.New("slicer: cursor pages cannot sort by a nullable field")
+)
+
+// cursorSecret signs cursor tokens for paginators that do not configure
+// Config.CursorSecret. It is unset until SetCursorSecret is called.
+var cursorSecret atomic.Pointer[[]byte]
+
+// SetCursorSecret sets the key used to sign cursor tokens of paginators
+// without Config.CursorSecret. Services running several instances behind a
+// load balancer must share the same secret so that a token issued by one
+// instance is accepted by the others. It is safe to call concurrently with
+// running queries.
+func SetCursorSecret(secret []byte) {
+	secret = append([]byte{}, secret...)
+	cursorSecret.Store(&secret)
+}
+
+// defaultCursorKey is the tiebreaker column used for cursor pagination when
+// the paginator does not configure one.
+const defaultCursorKey = "id"
+
+type (
+	// CursorQuery switches a query into keyset (cursor) pagination. After and
+	// Before hold opaque tokens taken from PageData.NextCursor and
+	// PageData.PrevCursor. An empty CursorQuery requests the first page.
+	CursorQuery struct {
+		After  string
+		Before string
+	}
+
+	// cursorPayload is the decoded form of a cursor token. Keys records the
+	// keyset the values were taken from so that a token cannot be replayed
+	// against a different sort order. Ties counts the items sharing the same
+	// keyset values that the cursor has already moved past; it is only used
+	// by SlicePage, where the keyset is not guaranteed to be unique.
+	cursorPayload struct {
+		Keys   []string `json:"k"`
+		Values []string `json:"v"`
+		Ties   int      `json:"t,omitempty"`
+	}
+)
+
+// keysetFields returns the ordered fields used for keyset pagination: the
+// allowed sort fields followed by the unique tiebreaker key. The tiebreaker
+// follows the direction of the last sort field.
+func keysetFields(sortFields []SortField, allowed map[string]string, key string) []SortField {
+	if key == "" {
+		key = defaultCursorKey
+	}
+
+	fields := make([]SortField, 0, len(sortFields)+1)
+	hasKey := false
+	desc := false
+	for _, s := range sortFields {
+		if _, ok := allowed[s.Field]; !ok {
+			continue
+		}
+		fields = append(fields, s)
+		desc = s.Desc
+		if s.Field == key {
+			hasKey = true
+		}
+	}
+
+	if _, ok := allowed[key]; ok && !hasKey {
+		fields = append(fields, SortField{Field: key, Desc: desc})
+	}
+	return fields
+}
+
+// checkKeyset returns ErrNullableCursorField when a keyset field of the
+// struct type modelType can hold NULL. Fields that are no struct field, such
+// as those only known to registered accessors, are not checked.
+func checkKeyset(modelType reflect.Type, fields []SortField) error {
+	if modelType.Kind() == reflect.Ptr {
+		modelType = modelType.Elem()
+	}
+	if modelType.Kind() != reflect.Struct {
+		return nil
+	}
+	for _, f := range fields {
+		if isNullableField(modelType, f.Field) {
+			return fmt.Errorf("%w: %s", ErrNullableCursorField, f.Field)
+		}
+	}
+	return nil
+}
+
+// isNullableField reports whether the struct field matching name holds a
+// pointer, interface, map or slice, or a driver.Valuer such as the typedef
+// types and sql.NullString, all of which isNull may find NULL.
+func isNullableField(modelType reflect.Type, name string) bool {
+	i := structFieldsOf(modelType).index(name)
+	if i < 0 {
+		return false
+	}
+	t := modelType.Field(i).Type
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Map, reflect.Slice:
+		return true
+	}
+	valuer := reflect.TypeFor[driver.Valuer]()
+	return t.Implements(valuer) || reflect.PointerTo(t).Implements(valuer)
+}
+
+// keysetKeys renders the keyset as "field" / "-field" strings.
+func keysetKeys(fields []SortField) []string {
+	keys := make([]string, len(fields))
+	for i, f := range fields {
+		if f.Desc {
+			keys[i] = "-" + f.Field
+		} else {
+			keys[i] = f.Field
+		}
+	}
+	return keys
+}
+
+// cursorSecret returns the key used to sign cursor tokens, or
+// ErrNoCursorSecret when neither the config nor SetCursorSecret set one.
+func (c Config) cursorSecret() ([]byte, error) {
+	if len(c.CursorSecret) > 0 {
+		return c.CursorSecret, nil
+	}
+	if secret := cursorSecret.Load(); secret != nil && len(*secret) > 0 {
+		return *secret, nil
+	}
+	return nil, ErrNoCursorSecret
+}
+
+// encodeCursor builds an opaque, signed token from the keyset values of an
+// item. The token is the base64 payload followed by a truncated HMAC.
+func encodeCursor(secret []byte, fields []SortField, values []string, ties int) string {
+	payload, _ := json.Marshal(cursorPayload{
+		Keys:   keysetKeys(fields),
+		Values: values,
+		Ties:   ties,
+	})
+	return base64.RawURLEncoding.EncodeToString(payload) + "." +
+		base64.RawURLEncoding.EncodeToString(signCursor(secret, payload))
+}
+
+// decodeCursor verifies a token's signature, validates it against the
+// current keyset and returns its payload.
+func decodeCursor(secret []byte, token string, fields []SortField) (cursorPayload, error) {
+	var payload cursorPayload
+
+	data, sig, ok := strings.Cut(token, ".")
+	if !ok {
+		return payload, ErrInvalidCursor
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(data)
+	if err != nil {
+		return payload, ErrInvalidCursor
+	}
+	mac, err := base64.RawURLEncoding.DecodeString(sig)
+	if err != nil || !hmac.Equal(mac, signCursor(secret, raw)) {
+		return payload, ErrInvalidCursor
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&payload); err != nil || payload.Ties < 0 {
+		return payload, ErrInvalidCursor
+	}
+
+	keys := keysetKeys(fields)
+	if len(payload.Keys) != len(keys) || len(payload.Values) != len(keys) {
+		return payload, ErrInvalidCursor
+	}
+	for i := range keys {
+		if payload.Keys[i] != keys[i] {
+			return payload, ErrInvalidCursor
+		}
+	}
+	return payload, nil
+}
+
+func signCursor(secret, payload []byte) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(payload)
+	return mac.Sum(nil)[:16]
+}
+
+// cursorValues extracts the keyset values from item, through the
+// registered accessors of T or else reflection.
+func cursorValues[T any](item T, fields []SortField) []string {
+	access := accessorsOf[T]()
+	values := make([]string, len(fields))
+	for i, f := range fields {
+		if field, ok := access.field(item, f.Field); ok {
+			values[i] = formatCursorValue(field)
+		}
+	}
+	return values
+}
+
+// formatCursorValue converts a field value into the string stored in a
+// cursor. The result is accepted by compare and by SQL drivers as a bind
+// argument.
+func formatCursorValue(v any) string {
+	switch x := v.(type) {
+	case string:
+		return x
+	case typedef.Integer:
+		return x.String()
+	case typedef.Float:
+		return x.String()
+	case typedef.Date:
+		return x.String()
+	case typedef.Datetime:
+		return x.String()
+	case time.Time:
+		return x.Format(time.RFC3339Nano)
+	case int, int64, float32, float64:
+		return toString(x)
+	default:
+		return fmt.Sprintf("%v", x)
+	}
+}
+
+// keysetCondition builds the WHERE clause selecting rows strictly after (or
+// before, when backward is true) the given keyset values. When every field
+// shares a direction a row-value comparison `(a, b) > (?, ?)` is emitted,
+// otherwise the comparison is expanded into OR-ed prefixes.
+func keysetCondition(columns []string, fields []SortField, values []string, backward bool) (string, []any) {
+	operator := func(desc bool) string {
+		if desc != backward {
+			return "<"
+		}
+		return ">"
+	}
+
+	uniform := true
+	for _, f := range fields[1:] {
+		if f.Desc != fields[0].Desc {
+			uniform = false
+			break
+		}
+	}
+
+	args := make([]any, 0, len(values))
+	if uniform {
+		for _, v := range values {
+			args = append(args, v)
+		}
+		if len(columns) == 1 {
+			return fmt.Sprintf("%s %s ?", columns[0], operator(fields[0].Desc)), args
+		}
+		placeholders := strings.TrimRight(strings.Repeat("?, ", len(columns)), ", ")
+		return fmt.Sprintf("(%s) %s (%s)", strings.Join(columns, ", "), operator(fields[0].Desc), placeholders), args
+	}
+
+	clauses := make([]string, 0, len(columns))
+	for i := range columns {
+		parts := make([]string, 0, i+1)
+		for j := 0; j < i; j++ {
+			parts = append(parts, fmt.Sprintf("%s = ?", columns[j]))
+			args = append(args, values[j])
+		}
+		parts = append(parts, fmt.Sprintf("%s %s ?", columns[i], operator(fields[i].Desc)))
+		args = append(args, values[i])
+		clauses = append(clauses, "("+strings.Join(parts, " AND ")+")")
+	}
+	return "(" + strings.Join(clauses, " OR ") + ")", args
+}
+
+// keysetCompare orders item against cursor values: -1 when item sorts
+// before them, 1 when after and 0 when the keyset values are equal. Values
+// that cannot be compared are treated as equal.
+func keysetCompare[T any](item T, fields []SortField, values []string) int {
+	access := accessorsOf[T]()
+	for i, f := range fields {
+		actual, ok := access.field(item, f.Field)
+		if !ok {
+			continue
+		}
+		if formatCursorValue(actual) == values[i] {
+			continue
+		}
+
+		less := compare(actual, values[i], LT)
+		if !less && !compare(actual, values[i], GT) {
+			continue
+		}
+		if less != f.Desc {
+			return -1
+		}
+		return 1
+	}
+	return 0
+}
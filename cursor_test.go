@@ -0,0 +1,123 @@
+package slicer
+
+import (
+	"database/sql"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/godev90/validator/typedef"
+)
+
+func TestKeysetCondition(t *testing.T) {
+	t.Run("Uniform ascending uses row comparison", func(t *testing.T) {
+		fields := []SortField{{Field: "name"}, {Field: "id"}}
+		cond, args := keysetCondition([]string{"name", "id"}, fields, []string{"Bob", "7"}, false)
+
+		if cond != "(name, id) > (?, ?)" {
+			t.Errorf("Unexpected condition: %s", cond)
+		}
+		if !reflect.DeepEqual(args, []any{"Bob", "7"}) {
+			t.Errorf("Unexpected args: %v", args)
+		}
+	})
+
+	t.Run("Backward flips the operator", func(t *testing.T) {
+		fields := []SortField{{Field: "created_at", Desc: true}, {Field: "id", Desc: true}}
+		cond, _ := keysetCondition([]string{"created_at", "id"}, fields, []string{"2024-01-01", "7"}, true)
+
+		if cond != "(created_at, id) > (?, ?)" {
+			t.Errorf("Unexpected condition: %s", cond)
+		}
+	})
+
+	t.Run("Single column", func(t *testing.T) {
+		cond, args := keysetCondition([]string{"id"}, []SortField{{Field: "id", Desc: true}}, []string{"9"}, false)
+
+		if cond != "id < ?" {
+			t.Errorf("Unexpected condition: %s", cond)
+		}
+		if len(args) != 1 {
+			t.Errorf("Expected 1 arg, got %d", len(args))
+		}
+	})
+
+	t.Run("Mixed directions expand", func(t *testing.T) {
+		fields := []SortField{{Field: "age", Desc: true}, {Field: "id"}}
+		cond, args := keysetCondition([]string{"age", "id"}, fields, []string{"30", "4"}, false)
+
+		if cond != "((age < ?) OR (age = ? AND id > ?))" {
+			t.Errorf("Unexpected condition: %s", cond)
+		}
+		if !reflect.DeepEqual(args, []any{"30", "30", "4"}) {
+			t.Errorf("Unexpected args: %v", args)
+		}
+	})
+}
+
+func TestCursorEncoding(t *testing.T) {
+	allowed := map[string]string{"id": "id", "name": "name"}
+	fields := keysetFields([]SortField{{Field: "name"}, {Field: "unknown"}}, allowed, "")
+
+	if !reflect.DeepEqual(fields, []SortField{{Field: "name"}, {Field: "id"}}) {
+		t.Fatalf("Unexpected keyset: %v", fields)
+	}
+
+	type item struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}
+
+	secret := []byte("secret")
+	token := encodeCursor(secret, fields, cursorValues(item{ID: 3, Name: "Carol"}, fields), 0)
+	cursor, err := decodeCursor(secret, token, fields)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(cursor.Values, []string{"Carol", "3"}) {
+		t.Errorf("Unexpected values: %v", cursor.Values)
+	}
+
+	if _, err := decodeCursor(secret, token, []SortField{{Field: "name", Desc: true}, {Field: "id"}}); err != ErrInvalidCursor {
+		t.Errorf("Expected ErrInvalidCursor for mismatched keyset, got %v", err)
+	}
+	if _, err := decodeCursor(secret, "not a cursor!", fields); err != ErrInvalidCursor {
+		t.Errorf("Expected ErrInvalidCursor for garbage token, got %v", err)
+	}
+	if _, err := decodeCursor([]byte("other"), token, fields); err != ErrInvalidCursor {
+		t.Errorf("Expected ErrInvalidCursor for foreign signature, got %v", err)
+	}
+
+	// swap the payload while keeping the original signature
+	forged := encodeCursor([]byte("attacker"), fields, []string{"Zed", "99"}, 0)
+	_, sig, _ := strings.Cut(token, ".")
+	data, _, _ := strings.Cut(forged, ".")
+	if _, err := decodeCursor(secret, data+"."+sig, fields); err != ErrInvalidCursor {
+		t.Errorf("Expected ErrInvalidCursor for tampered payload, got %v", err)
+	}
+}
+
+func TestCheckKeyset(t *testing.T) {
+	type model struct {
+		ID      int             `json:"id"`
+		Name    string          `json:"name"`
+		Created time.Time       `json:"created"`
+		Note    *string         `json:"note"`
+		Score   typedef.Integer `json:"score"`
+		Code    sql.NullString  `json:"code"`
+	}
+	modelType := reflect.TypeOf(&model{})
+
+	for _, field := range []string{"id", "name", "created", "rank"} {
+		if err := checkKeyset(modelType, []SortField{{Field: field}}); err != nil {
+			t.Errorf("%s: unexpected error %v", field, err)
+		}
+	}
+	for _, field := range []string{"note", "score", "code"} {
+		if err := checkKeyset(modelType, []SortField{{Field: field}, {Field: "id"}}); !errors.Is(err, ErrNullableCursorField) {
+			t.Errorf("%s: expected ErrNullableCursorField, got %v", field, err)
+		}
+	}
+}
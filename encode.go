@@ -0,0 +1,111 @@
+package slicer
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// ToValues converts the options back into URL query values that ParseOpts
+// parses into the same options. Page and limit are always written; Offset is
+// derived from them and not encoded. Options using a custom Separator must
+// be parsed back with a Parser using that separator.
+func (o QueryOptions) ToValues() url.Values {
+	return defaultParser().ToValues(o)
+}
+
+// Encode renders the options as a canonical query string: keys are sorted
+// and equal options always produce the same string. See ToValues.
+func (o QueryOptions) Encode() string {
+	return o.ToValues().Encode()
+}
+
+// ToValues is QueryOptions.ToValues using the parser's parameter names and,
+// unless o sets its own, the parser's separator.
+func (p *Parser) ToValues(o QueryOptions) url.Values {
+	var (
+		names  = p.params()
+		sep    = p.separator()
+		values = url.Values{}
+	)
+	if o.Separator != "" {
+		sep = o.Separator
+	}
+
+	if o.Page > 0 {
+		values.Set(names.Page, strconv.Itoa(o.Page))
+	}
+	if o.Limit > 0 {
+		values.Set(names.Limit, strconv.Itoa(o.Limit))
+	}
+
+	if len(o.Sort) > 0 {
+		fields := make([]string, len(o.Sort))
+		for i, s := range o.Sort {
+			if s.Desc {
+				fields[i] = "-" + s.Field
+			} else {
+				fields[i] = s.Field
+			}
+		}
+		values.Set(names.Sort, strings.Join(fields, sep))
+	}
+
+	if o.Search != nil && len(o.Search.Fields) > 0 && o.Search.Keyword != "" {
+		values.Set(names.Search, strings.Join(o.Search.Fields, sep))
+		values.Set(names.Keyword, o.Search.Keyword)
+	}
+
+	if o.SearchAnd != nil {
+		prefix := names.SearchAnd
+		if prefix == "" {
+			prefix = "search_and"
+		}
+		for _, f := range o.SearchAnd.Fields {
+			if f != nil && f.Field != "" && f.Keyword != "" {
+				values.Add(prefix+"."+f.Field, f.Keyword)
+			}
+		}
+	}
+
+	if len(o.GroupBy) > 0 {
+		// parsing appends the sort fields to the group and selects the
+		// group, so only the group itself is written
+		group := o.GroupBy
+		if n := len(group) - len(o.Sort); n > 0 {
+			trailing := true
+			for i, s := range o.Sort {
+				if group[n+i] != s.Field {
+					trailing = false
+					break
+				}
+			}
+			if trailing {
+				group = group[:n]
+			}
+		}
+		values.Set(names.Group, strings.Join(group, sep))
+	} else if len(o.Select) > 0 {
+		values.Set(names.Select, strings.Join(o.Select, sep))
+	}
+
+	if o.Cursor != nil {
+		values.Set(names.Cursor, o.Cursor.After)
+		if o.Cursor.Before != "" {
+			values.Set(names.Before, o.Cursor.Before)
+		}
+	}
+
+	if o.Filter != nil {
+		values.Set(names.Filter, o.Filter.format(sep))
+	}
+
+	for _, cmp := range o.Comparisons {
+		values.Add(cmp.Field+"["+string(cmp.Op)+"]", cmp.Value)
+	}
+
+	for key, val := range o.Filters {
+		values.Set(key, val)
+	}
+	return values
+}
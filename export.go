@@ -0,0 +1,457 @@
+package slicer
+
+import (
+	"bufio"
+	"context"
+	"database/sql/driver"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"reflect"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/godev90/orm"
+)
+
+var (
+	ErrUnsupportedFormat error = errors.New("slicer: unsupported export format")
+)
+
+// ExportFormat is the serialization of an export.
+type ExportFormat string
+
+const (
+	ExportCSV    ExportFormat = "csv"
+	ExportNDJSON ExportFormat = "ndjson"
+	ExportJSON   ExportFormat = "json"
+	ExportXLSX   ExportFormat = "xlsx"
+)
+
+// defaultExportBatch is the number of rows read per query when
+// Config.ExportBatchSize is unset.
+const defaultExportBatch = 1000
+
+// exportMediaTypes maps media types to the formats they select.
+var exportMediaTypes = map[string]ExportFormat{
+	"text/csv":             ExportCSV,
+	"application/csv":      ExportCSV,
+	"application/x-ndjson": ExportNDJSON,
+	"application/ndjson":   ExportNDJSON,
+	"application/jsonl":    ExportNDJSON,
+	"application/json":     ExportJSON,
+	xlsxMediaType:          ExportXLSX,
+}
+
+const xlsxMediaType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+
+// ExportWriter receives the rows of an export: the column names once, then
+// one call per row with the raw field values in column order.
+type ExportWriter interface {
+	WriteHeader(columns []string) error
+	WriteRow(values []any) error
+	Close() error
+}
+
+// ParseExportFormat returns the format named by s, either a format name
+// (csv, ndjson, json, xlsx) or a media type such as text/csv.
+func ParseExportFormat(s string) (ExportFormat, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	switch format := ExportFormat(s); format {
+	case ExportCSV, ExportNDJSON, ExportJSON, ExportXLSX:
+		return format, nil
+	case "jsonl":
+		return ExportNDJSON, nil
+	}
+	if mediaType, _, err := mime.ParseMediaType(s); err == nil {
+		if format, ok := exportMediaTypes[mediaType]; ok {
+			return format, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %q", ErrUnsupportedFormat, s)
+}
+
+// NegotiateExportFormat picks the export format preferred by an Accept
+// header, honouring q-values. An empty header or */* selects JSON.
+func NegotiateExportFormat(accept string) (ExportFormat, error) {
+	if strings.TrimSpace(accept) == "" {
+		return ExportJSON, nil
+	}
+
+	type choice struct {
+		format ExportFormat
+		q      float64
+	}
+	var choices []choice
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		q := 1.0
+		if v, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(v, 64); err != nil || q <= 0 {
+				continue
+			}
+		}
+		switch format, ok := exportMediaTypes[mediaType]; {
+		case ok:
+			choices = append(choices, choice{format, q})
+		case mediaType == "*/*" || mediaType == "application/*":
+			choices = append(choices, choice{ExportJSON, q})
+		case mediaType == "text/*":
+			choices = append(choices, choice{ExportCSV, q})
+		}
+	}
+	if len(choices) == 0 {
+		return "", fmt.Errorf("%w: %q", ErrUnsupportedFormat, accept)
+	}
+	sort.SliceStable(choices, func(i, j int) bool { return choices[i].q > choices[j].q })
+	return choices[0].format, nil
+}
+
+// ContentType returns the media type of the format.
+func (f ExportFormat) ContentType() string {
+	switch f {
+	case ExportCSV:
+		return "text/csv; charset=utf-8"
+	case ExportNDJSON:
+		return "application/x-ndjson"
+	case ExportXLSX:
+		return xlsxMediaType
+	}
+	return "application/json"
+}
+
+// NewExportWriter returns an ExportWriter writing format to w. Close
+// flushes the output and, for JSON, ends the array. XLSX uses the default
+// XLSXOptions; call NewXLSXWriter to configure it.
+func NewExportWriter(w io.Writer, format ExportFormat) (ExportWriter, error) {
+	switch format {
+	case ExportCSV:
+		return &csvExport{w: csv.NewWriter(w)}, nil
+	case ExportNDJSON, ExportJSON:
+		return &jsonExport{w: bufio.NewWriter(w), array: format == ExportJSON}, nil
+	case ExportXLSX:
+		return NewXLSXWriter(w, XLSXOptions{})
+	}
+	return nil, fmt.Errorf("%w: %q", ErrUnsupportedFormat, format)
+}
+
+// ExportColumns returns the columns exported for opts: the selected fields
+// in order, or every allowed field sorted by name.
+func ExportColumns(opts QueryOptions, allowed map[string]string) []string {
+	if len(opts.Select) > 0 {
+		columns := make([]string, 0, len(opts.Select))
+		for _, field := range opts.Select {
+			if _, ok := allowed[field]; ok || allowed == nil {
+				columns = append(columns, field)
+			}
+		}
+		return columns
+	}
+	columns := make([]string, 0, len(allowed))
+	for field := range allowed {
+		columns = append(columns, field)
+	}
+	sort.Strings(columns)
+	return columns
+}
+
+// Export streams the rows matching opts to w in the given format. See
+// ExportTo.
+func Export[T orm.Tabler](ctx context.Context, w io.Writer, format ExportFormat, paginator Paginator[T], opts QueryOptions) error {
+	ew, err := NewExportWriter(w, format)
+	if err != nil {
+		return err
+	}
+	if err := ExportTo(ctx, ew, paginator, opts); err != nil {
+		return err
+	}
+	return ew.Close()
+}
+
+// ExportTo streams the rows DownloadPage would return to ew, reading them
+// in batches of Config.ExportBatchSize so that memory use does not grow with
+// the result. Batches are read in keyset order, or by offset for grouped
+// queries, models without the cursor key and sorts by nullable fields, and
+// written as they are scanned. Offset batches are ordered by every group
+// column, or every selected column, after opts.Sort; without any such column
+// the rows are read in one query. The header is only written once the first
+// batch succeeded, so errors in the query itself reach the caller before any
+// output. Limits apply as in DownloadPage: in reject mode a result over
+// MaxDownloadRows is refused up front with ErrLimitExceeded. ExportTo does
+// not close ew.
+func ExportTo[T orm.Tabler](ctx context.Context, ew ExportWriter, paginator Paginator[T], opts QueryOptions) error {
+	var (
+		config  = configOf(paginator)
+		limits  = config.Limits
+		maxRows = limits.MaxDownloadRows
+		batch   = config.ExportBatchSize
+		columns = ExportColumns(opts, paginator.AllowedFields())
+	)
+	if batch <= 0 {
+		batch = defaultExportBatch
+	}
+
+	limits.MaxLimit, limits.MaxPage = 0, 0
+	opts, err := limits.apply(opts)
+	if err != nil {
+		return err
+	}
+
+	if maxRows > 0 && limits.Mode == LimitReject {
+		probe := opts
+		probe.Page, probe.Offset, probe.Limit, probe.Count, probe.Cursor = 1, 0, 1, CountExact, nil
+		data, err := queryPage(ctx, paginator, probe)
+		if err != nil {
+			return err
+		}
+		if data.Total > int64(maxRows) {
+			return fmt.Errorf("%w: download rows exceed %d", ErrLimitExceeded, maxRows)
+		}
+	}
+
+	// keyset batches need the unique cursor key, which grouped rows lack,
+	// and a keyset that cannot be NULL
+	key := config.CursorKey
+	if key == "" {
+		key = defaultCursorKey
+	}
+	_, keyed := paginator.AllowedFields()[key]
+	if keyed {
+		keyset := keysetFields(opts.Sort, paginator.AllowedFields(), config.CursorKey)
+		keyed = checkKeyset(reflect.TypeOf(paginator.Model()), keyset) == nil
+	}
+
+	opts.Page, opts.Offset, opts.Limit, opts.Count, opts.Cursor = 1, 0, batch, CountNone, nil
+	if keyed && len(opts.GroupBy) == 0 {
+		opts.Cursor = &CursorQuery{}
+	} else if order, ok := exportOrder(opts, paginator.AllowedFields()); ok {
+		// offset batches only line up under a total order
+		opts.Sort = order
+	} else {
+		opts.Limit = maxRows
+	}
+
+	written := 0
+	for {
+		if maxRows > 0 && written+opts.Limit > maxRows {
+			opts.Limit = maxRows - written
+		}
+		data, err := queryPage(ctx, paginator, opts)
+		if err != nil {
+			return err
+		}
+		if written == 0 {
+			if err := ew.WriteHeader(columns); err != nil {
+				return err
+			}
+		}
+
+		items := reflect.ValueOf(data.Items)
+		for i := 0; i < items.Len(); i++ {
+			if err := ew.WriteRow(exportValues(items.Index(i), columns)); err != nil {
+				return err
+			}
+		}
+		written += items.Len()
+
+		if !data.HasMore || items.Len() == 0 || maxRows > 0 && written >= maxRows {
+			return nil
+		}
+		if opts.Cursor != nil {
+			opts.Cursor = &CursorQuery{After: data.NextCursor}
+		} else {
+			opts.Offset = written
+		}
+	}
+}
+
+// exportOrder extends the sort of opts to a total order over the exported
+// rows: every group column of grouped queries, otherwise every selected
+// column. It reports false when no column can be ordered by.
+func exportOrder(opts QueryOptions, allowed map[string]string) ([]SortField, bool) {
+	var columns []string
+	for _, field := range opts.GroupBy {
+		if _, ok := allowed[field]; ok {
+			columns = append(columns, field)
+		}
+	}
+	if len(columns) == 0 {
+		columns = ExportColumns(opts, allowed)
+	}
+	if len(columns) == 0 {
+		return nil, false
+	}
+
+	order := slices.Clone(opts.Sort)
+	for _, field := range columns {
+		if !slices.ContainsFunc(order, func(s SortField) bool { return s.Field == field }) {
+			order = append(order, SortField{Field: field})
+		}
+	}
+	return order, true
+}
+
+// ExportPage writes the items of a page to ew, for results already held in
+// memory such as SlicePage pages. It does not close ew.
+func ExportPage(ew ExportWriter, data PageData, columns []string) error {
+	if err := ew.WriteHeader(columns); err != nil {
+		return err
+	}
+	items := reflect.ValueOf(data.Items)
+	if items.Kind() != reflect.Slice {
+		return nil
+	}
+	for i := 0; i < items.Len(); i++ {
+		if err := ew.WriteRow(exportValues(items.Index(i), columns)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// exportValues returns the raw values of the columns of item, nil for
+// missing fields, nil pointers and unset dates.
+func exportValues(item reflect.Value, columns []string) []any {
+	values := make([]any, len(columns))
+	for i, column := range columns {
+		field := findFieldByColumn(item, column)
+		for field.IsValid() && field.Kind() == reflect.Ptr {
+			if field.IsNil() {
+				field = reflect.Value{}
+				break
+			}
+			field = field.Elem()
+		}
+		if field.IsValid() && field.CanInterface() {
+			values[i] = field.Interface()
+		}
+		if isZeroTime(values[i]) {
+			values[i] = nil
+		}
+	}
+	return values
+}
+
+// isZeroTime reports whether v is an unset time.Time, typedef.Date or
+// typedef.Datetime, which are exported as empty cells.
+func isZeroTime(v any) bool {
+	switch t := v.(type) {
+	case time.Time:
+		return t.IsZero()
+	case interface{ Time() time.Time }:
+		return t.Time().IsZero()
+	}
+	return false
+}
+
+// exportText renders a value as text: SQL values as stored, times as
+// RFC 3339 and everything else with fmt.
+func exportText(v any) string {
+	if valuer, ok := v.(driver.Valuer); ok {
+		value, err := valuer.Value()
+		if err != nil {
+			return ""
+		}
+		v = value
+	}
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	case time.Time:
+		return v.Format(time.RFC3339)
+	case fmt.Stringer:
+		return v.String()
+	}
+	return fmt.Sprint(v)
+}
+
+type csvExport struct {
+	w *csv.Writer
+}
+
+func (e *csvExport) WriteHeader(columns []string) error {
+	return e.w.Write(columns)
+}
+
+func (e *csvExport) WriteRow(values []any) error {
+	record := make([]string, len(values))
+	for i, v := range values {
+		record[i] = exportText(v)
+	}
+	return e.w.Write(record)
+}
+
+func (e *csvExport) Close() error {
+	e.w.Flush()
+	return e.w.Error()
+}
+
+// jsonExport writes rows as JSON objects keyed by column, one per line or
+// as the elements of an array.
+type jsonExport struct {
+	w       *bufio.Writer
+	array   bool
+	started bool
+	columns []string
+	rows    int
+}
+
+func (e *jsonExport) WriteHeader(columns []string) error {
+	e.columns, e.started = columns, true
+	if e.array {
+		_, err := e.w.WriteString("[")
+		return err
+	}
+	return nil
+}
+
+func (e *jsonExport) WriteRow(values []any) error {
+	if e.array && e.rows > 0 {
+		e.w.WriteString(",")
+	}
+	e.rows++
+
+	e.w.WriteString("{")
+	for i, column := range e.columns {
+		if i > 0 {
+			e.w.WriteString(",")
+		}
+		key, _ := json.Marshal(column)
+		value, err := json.Marshal(values[i])
+		if err != nil {
+			return err
+		}
+		e.w.Write(key)
+		e.w.WriteString(":")
+		e.w.Write(value)
+	}
+	_, err := e.w.WriteString("}")
+	if !e.array {
+		_, err = e.w.WriteString("\n")
+	}
+	return err
+}
+
+func (e *jsonExport) Close() error {
+	if e.array {
+		if !e.started {
+			// nothing was exported: still emit a valid empty array
+			e.w.WriteString("[")
+		}
+		e.w.WriteString("]\n")
+	}
+	return e.w.Flush()
+}
@@ -0,0 +1,32 @@
+package slicer
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExportOrder(t *testing.T) {
+	allowed := map[string]string{"id": "id", "team": "team", "region": "region"}
+
+	tests := []struct {
+		name string
+		opts QueryOptions
+		want []SortField
+	}{
+		{"Group columns", QueryOptions{GroupBy: []string{"team", "region"}, Sort: []SortField{{Field: "region", Desc: true}}},
+			[]SortField{{Field: "region", Desc: true}, {Field: "team"}}},
+		{"Selected columns", QueryOptions{Select: []string{"team", "id"}},
+			[]SortField{{Field: "team"}, {Field: "id"}}},
+		{"Every allowed column", QueryOptions{Sort: []SortField{{Field: "team"}}},
+			[]SortField{{Field: "team"}, {Field: "id"}, {Field: "region"}}},
+	}
+	for _, tt := range tests {
+		if got, ok := exportOrder(tt.opts, allowed); !ok || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: expected %v, got %v (%v)", tt.name, tt.want, got, ok)
+		}
+	}
+
+	if _, ok := exportOrder(QueryOptions{Select: []string{"unknown"}}, allowed); ok {
+		t.Error("Expected no total order without an allowed column")
+	}
+}
@@ -0,0 +1,61 @@
+package slicer
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// structFieldCache maps a struct reflect.Type to its *structFields.
+var structFieldCache sync.Map
+
+// structFields holds the parsed json tags of a struct type and the field
+// index of every column looked up so far. It is built once per type and
+// shared by all goroutines.
+type structFields struct {
+	names   []string // Go names
+	json    []string // JSON names, valid where tagged is true
+	tagged  []bool   // whether the field has a json tag other than "-"
+	columns sync.Map // column -> field index, -1 when no field matches
+}
+
+// structFieldsOf returns the cached fields of the struct type t.
+func structFieldsOf(t reflect.Type) *structFields {
+	if cached, ok := structFieldCache.Load(t); ok {
+		return cached.(*structFields)
+	}
+
+	fields := &structFields{
+		names:  make([]string, t.NumField()),
+		json:   make([]string, t.NumField()),
+		tagged: make([]bool, t.NumField()),
+	}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		fields.names[i] = field.Name
+		if tag := field.Tag.Get("json"); tag != "" && tag != "-" {
+			fields.json[i], fields.tagged[i] = strings.Split(tag, ",")[0], true
+		}
+	}
+
+	cached, _ := structFieldCache.LoadOrStore(t, fields)
+	return cached.(*structFields)
+}
+
+// index returns the index of the first field whose JSON name is column or
+// whose name equals it ignoring case, or -1.
+func (f *structFields) index(column string) int {
+	if i, ok := f.columns.Load(column); ok {
+		return i.(int)
+	}
+
+	index := -1
+	for i, name := range f.names {
+		if (f.tagged[i] && f.json[i] == column) || strings.EqualFold(name, column) {
+			index = i
+			break
+		}
+	}
+	f.columns.Store(column, index)
+	return index
+}
@@ -0,0 +1,102 @@
+package slicer
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type indexedRow struct {
+	ID      int    `json:"id"`
+	Name    string `json:"full_name,omitempty"`
+	Nick    string `json:"name"`
+	Skipped string `json:"-"`
+	Status  string
+}
+
+// scanFieldByColumn is the uncached lookup findFieldByColumn replaced.
+func scanFieldByColumn(v reflect.Value, column string) reflect.Value {
+	t := v.Type()
+	for i := 0; i < v.NumField(); i++ {
+		field := t.Field(i)
+		jsonTag := field.Tag.Get("json")
+		if jsonTag != "" && jsonTag != "-" {
+			if strings.Split(jsonTag, ",")[0] == column {
+				return v.Field(i)
+			}
+		}
+		if strings.EqualFold(field.Name, column) {
+			return v.Field(i)
+		}
+	}
+	return reflect.Value{}
+}
+
+func TestStructFieldCache(t *testing.T) {
+	row := indexedRow{ID: 1, Name: "Ann Lee", Nick: "ann", Skipped: "x", Status: "open"}
+	v := reflect.ValueOf(row)
+	columns := []string{"id", "ID", "full_name", "name", "Name", "nick", "skipped", "-", "status", "missing", ""}
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for _, column := range columns {
+				got, want := findFieldByColumn(v, column), scanFieldByColumn(v, column)
+				if got.IsValid() != want.IsValid() || got.IsValid() && got.Interface() != want.Interface() {
+					t.Errorf("%q: expected %v, got %v", column, want, got)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := findFieldByColumn(reflect.ValueOf(&row), "status"); got.String() != "open" {
+		t.Errorf("Expected pointers to be dereferenced, got %v", got)
+	}
+	if fields := DefaultFilterByJson[indexedRow](); len(fields) != 5 || fields["full_name"] == "" || fields["skipped"] == "" {
+		t.Errorf("Unexpected default fields %v", fields)
+	}
+}
+
+func BenchmarkFindFieldByColumn(b *testing.B) {
+	v := reflect.ValueOf(indexedRow{Status: "open"})
+
+	b.Run("Scan", func(b *testing.B) {
+		for range b.N {
+			scanFieldByColumn(v, "status")
+		}
+	})
+	b.Run("Cached", func(b *testing.B) {
+		for range b.N {
+			findFieldByColumn(v, "status")
+		}
+	})
+}
+
+// BenchmarkFieldLookupRows looks a column up in each of 100k rows, the
+// pattern of SlicePage filtering and sorting, with and without the cache.
+func BenchmarkFieldLookupRows(b *testing.B) {
+	rows := make([]indexedRow, 100_000)
+	for i := range rows {
+		rows[i] = indexedRow{ID: i, Status: "open"}
+	}
+
+	lookups := map[string]func(reflect.Value, string) reflect.Value{
+		"Scan":   scanFieldByColumn,
+		"Cached": findFieldByColumn,
+	}
+	for name, lookup := range lookups {
+		b.Run(name, func(b *testing.B) {
+			for range b.N {
+				for i := range rows {
+					if !lookup(reflect.ValueOf(rows[i]), "status").IsValid() {
+						b.Fatal("status not found")
+					}
+				}
+			}
+		})
+	}
+}
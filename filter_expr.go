@@ -0,0 +1,514 @@
+package slicer
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+var (
+	ErrFilterField error = errors.New("slicer: field not allowed under or() or not()")
+)
+
+// maxFilterDepth bounds the nesting of parsed filter expressions.
+const maxFilterDepth = 32
+
+type (
+	// LogicOp is the boolean operator of a FilterExpr group.
+	LogicOp string
+
+	// FilterExpr is a node of a boolean filter tree. A leaf carries a single
+	// Comparison; a group combines its Children with Op. NOT groups have
+	// exactly one child.
+	FilterExpr struct {
+		Op         LogicOp
+		Children   []*FilterExpr
+		Comparison *ComparisonFilter
+	}
+
+	// SyntaxError reports malformed filter input. Pos is the 1-based
+	// position of the offending character in Input.
+	SyntaxError struct {
+		Input string
+		Pos   int
+		Msg   string
+	}
+)
+
+const (
+	// Logic operator constants.
+	LogicAnd LogicOp = "and"
+	LogicOr  LogicOp = "or"
+	LogicNot LogicOp = "not"
+)
+
+// And returns a group matching when every child matches.
+func And(children ...*FilterExpr) *FilterExpr {
+	return &FilterExpr{Op: LogicAnd, Children: children}
+}
+
+// Or returns a group matching when any child matches.
+func Or(children ...*FilterExpr) *FilterExpr {
+	return &FilterExpr{Op: LogicOr, Children: children}
+}
+
+// Not returns a group negating child.
+func Not(child *FilterExpr) *FilterExpr {
+	return &FilterExpr{Op: LogicNot, Children: []*FilterExpr{child}}
+}
+
+// Compare returns a leaf holding a single comparison.
+func Compare(field string, op ComparisonOp, value string) *FilterExpr {
+	return &FilterExpr{Comparison: &ComparisonFilter{Field: field, Op: op, Value: value}}
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("slicer: syntax error at position %d: %s", e.Pos, e.Msg)
+}
+
+// each calls fn for every comparison in the tree.
+func (e *FilterExpr) each(fn func(ComparisonFilter)) {
+	if e == nil {
+		return
+	}
+	if e.Comparison != nil {
+		fn(*e.Comparison)
+	}
+	for _, child := range e.Children {
+		child.each(fn)
+	}
+}
+
+// String renders the expression in the syntax accepted by ParseFilterExpr,
+// e.g. `and(eq(status,open),not(in(owner,bot,system)))`.
+func (e *FilterExpr) String() string {
+	return e.format(packageSeparator())
+}
+
+// format renders the expression, splitting list values with sep.
+func (e *FilterExpr) format(sep string) string {
+	if e == nil {
+		return ""
+	}
+
+	var b strings.Builder
+	if e.Comparison != nil {
+		cmp := e.Comparison
+		b.WriteString(string(cmp.Op))
+		b.WriteString("(")
+		b.WriteString(cmp.Field)
+		switch cmp.Op {
+		case IN, NIN, BETWEEN:
+			for _, v := range strings.Split(cmp.Value, sep) {
+				b.WriteString(",")
+				b.WriteString(quoteExprValue(v))
+			}
+		case ISNULL, NOTNULL:
+			if cmp.Value != "" {
+				b.WriteString(",")
+				b.WriteString(quoteExprValue(cmp.Value))
+			}
+		default:
+			b.WriteString(",")
+			b.WriteString(quoteExprValue(cmp.Value))
+		}
+		b.WriteString(")")
+		return b.String()
+	}
+
+	b.WriteString(string(e.Op))
+	b.WriteString("(")
+	for i, child := range e.Children {
+		if i > 0 {
+			b.WriteString(",")
+		}
+		b.WriteString(child.format(sep))
+	}
+	b.WriteString(")")
+	return b.String()
+}
+
+// quoteExprValue single-quotes values that would not survive as bare
+// arguments.
+func quoteExprValue(v string) string {
+	if v != "" && v == strings.TrimSpace(v) && !strings.ContainsAny(v, `,()'"\`) {
+		return v
+	}
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(v) + "'"
+}
+
+// ParseFilterExpr parses a filter expression such as
+//
+//	and(or(eq(status,open),eq(status,pending)),not(eq(owner,bot)))
+//
+// Groups are and(...), or(...) and not(...), nested at most 32 deep; leaves
+// are any comparison operator applied to a field and its values, e.g.
+// in(id,1,2,3), between(age,18,65) or isnull(deleted_at). Values may be
+// quoted with single or double quotes, in which case a backslash escapes the
+// next character.
+func ParseFilterExpr(input string) (*FilterExpr, error) {
+	return parseFilterExpr(input, packageSeparator())
+}
+
+// parseFilterExpr parses input, joining list values with sep.
+func parseFilterExpr(input string, sep string) (*FilterExpr, error) {
+	p := &exprParser{input: input, sep: sep}
+	expr, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpace()
+	if p.pos < len(p.input) {
+		return nil, p.errorf("unexpected %q after expression", p.input[p.pos])
+	}
+	return expr, nil
+}
+
+type exprParser struct {
+	input string
+	pos   int
+	sep   string
+	depth int
+}
+
+func (p *exprParser) errorf(format string, args ...any) error {
+	return &SyntaxError{Input: p.input, Pos: p.pos + 1, Msg: fmt.Sprintf(format, args...)}
+}
+
+func (p *exprParser) skipSpace() {
+	for p.pos < len(p.input) && (p.input[p.pos] == ' ' || p.input[p.pos] == '\t') {
+		p.pos++
+	}
+}
+
+func (p *exprParser) expect(c byte) error {
+	p.skipSpace()
+	if p.pos >= len(p.input) {
+		return p.errorf("expected %q, found end of input", c)
+	}
+	if p.input[p.pos] != c {
+		return p.errorf("expected %q, found %q", c, p.input[p.pos])
+	}
+	p.pos++
+	return nil
+}
+
+// peek reports whether the next non-blank character is c.
+func (p *exprParser) peek(c byte) bool {
+	p.skipSpace()
+	return p.pos < len(p.input) && p.input[p.pos] == c
+}
+
+func (p *exprParser) ident() (string, error) {
+	p.skipSpace()
+	start := p.pos
+	for p.pos < len(p.input) {
+		c := p.input[p.pos]
+		if !(c == '_' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z') {
+			break
+		}
+		p.pos++
+	}
+	if start == p.pos {
+		if p.pos >= len(p.input) {
+			return "", p.errorf("expected identifier, found end of input")
+		}
+		return "", p.errorf("expected identifier, found %q", p.input[p.pos])
+	}
+	return p.input[start:p.pos], nil
+}
+
+func (p *exprParser) value() (string, error) {
+	p.skipSpace()
+	if p.pos < len(p.input) && (p.input[p.pos] == '\'' || p.input[p.pos] == '"') {
+		quote := p.input[p.pos]
+		start := p.pos
+		p.pos++
+
+		var b strings.Builder
+		for p.pos < len(p.input) {
+			c := p.input[p.pos]
+			switch {
+			case c == '\\' && p.pos+1 < len(p.input):
+				b.WriteByte(p.input[p.pos+1])
+				p.pos += 2
+			case c == quote:
+				p.pos++
+				return b.String(), nil
+			default:
+				b.WriteByte(c)
+				p.pos++
+			}
+		}
+		p.pos = start
+		return "", p.errorf("unterminated quoted value")
+	}
+
+	start := p.pos
+	for p.pos < len(p.input) && p.input[p.pos] != ',' && p.input[p.pos] != ')' {
+		if c := p.input[p.pos]; c == '(' || c == '\'' || c == '"' {
+			return "", p.errorf("unexpected %q in value, quote the value", c)
+		}
+		p.pos++
+	}
+	return strings.TrimSpace(p.input[start:p.pos]), nil
+}
+
+func (p *exprParser) parseExpr() (*FilterExpr, error) {
+	p.skipSpace()
+	namePos := p.pos
+	name, err := p.ident()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expect('('); err != nil {
+		return nil, err
+	}
+
+	switch op := LogicOp(strings.ToLower(name)); op {
+	case LogicAnd, LogicOr, LogicNot:
+		if p.depth == maxFilterDepth {
+			p.pos = namePos
+			return nil, p.errorf("expression nested deeper than %d levels", maxFilterDepth)
+		}
+		p.depth++
+		defer func() { p.depth-- }()
+
+		expr := &FilterExpr{Op: op}
+		for {
+			child, err := p.parseExpr()
+			if err != nil {
+				return nil, err
+			}
+			expr.Children = append(expr.Children, child)
+			if !p.peek(',') {
+				break
+			}
+			p.pos++
+		}
+		if op == LogicNot && len(expr.Children) != 1 {
+			p.pos = namePos
+			return nil, p.errorf("not() takes exactly one expression")
+		}
+		return expr, p.expect(')')
+	}
+
+	op := ComparisonOp(strings.ToLower(name))
+	if !comparisonOps[op] {
+		p.pos = namePos
+		return nil, p.errorf("unknown operator %q", name)
+	}
+
+	field, err := p.ident()
+	if err != nil {
+		return nil, err
+	}
+
+	// lists are joined with the separator, so their values cannot hold it
+	list := op == IN || op == NIN || op == BETWEEN
+
+	var values []string
+	for p.peek(',') {
+		p.pos++
+		p.skipSpace()
+		start := p.pos
+		v, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+		if list && strings.Contains(v, p.sep) {
+			p.pos = start
+			return nil, p.errorf("%s() values must not contain %q", op, p.sep)
+		}
+		values = append(values, v)
+	}
+
+	switch op {
+	case ISNULL, NOTNULL:
+		if len(values) > 1 {
+			p.pos = namePos
+			return nil, p.errorf("%s() takes a field and an optional flag", op)
+		}
+	case BETWEEN:
+		if len(values) != 2 {
+			p.pos = namePos
+			return nil, p.errorf("between() takes a field and two bounds")
+		}
+	case IN, NIN:
+		if len(values) == 0 {
+			p.pos = namePos
+			return nil, p.errorf("%s() takes a field and at least one value", op)
+		}
+	default:
+		if len(values) != 1 {
+			p.pos = namePos
+			return nil, p.errorf("%s() takes a field and one value", op)
+		}
+	}
+
+	if err := p.expect(')'); err != nil {
+		return nil, err
+	}
+	return Compare(field, op, strings.Join(values, p.sep)), nil
+}
+
+// filterClause translates the tree into a parenthesised SQL condition.
+// Comparisons on fields that are not allowed are dropped, as are groups left
+// without children; an empty condition means nothing remains to filter on.
+func filterClause(e *FilterExpr, allowed map[string]string, modelType reflect.Type, postgres bool, sep string) (string, []any) {
+	if e == nil {
+		return "", nil
+	}
+
+	if e.Comparison != nil {
+		col, ok := allowed[e.Comparison.Field]
+		if !ok {
+			return "", nil
+		}
+		return comparisonClause(col, *e.Comparison, isDateField(modelType, e.Comparison.Field), postgres, sep)
+	}
+
+	var (
+		parts []string
+		args  []any
+	)
+	for _, child := range e.Children {
+		if cond, childArgs := filterClause(child, allowed, modelType, postgres, sep); cond != "" {
+			parts = append(parts, cond)
+			args = append(args, childArgs...)
+		}
+	}
+	if len(parts) == 0 {
+		return "", nil
+	}
+
+	switch e.Op {
+	case LogicAnd:
+		return "(" + strings.Join(parts, " AND ") + ")", args
+	case LogicOr:
+		return "(" + strings.Join(parts, " OR ") + ")", args
+	case LogicNot:
+		return "NOT (" + parts[0] + ")", args
+	default:
+		return "", nil
+	}
+}
+
+// checkFilterFields rejects comparisons on fields that are not allowed
+// below an or() or not() group, where dropping them would widen the match
+// instead of narrowing it. Below and() groups they are dropped.
+func checkFilterFields(e *FilterExpr, allowed map[string]string, widening bool) error {
+	if e == nil {
+		return nil
+	}
+	if e.Comparison != nil {
+		if _, ok := allowed[e.Comparison.Field]; !ok && widening {
+			return fmt.Errorf("%w: %s", ErrFilterField, e.Comparison.Field)
+		}
+		return nil
+	}
+	widening = widening || e.Op == LogicOr || e.Op == LogicNot
+	for _, child := range e.Children {
+		if err := checkFilterFields(child, allowed, widening); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// filterTruth is a truth value of SQL's three-valued logic, in which
+// comparisons against NULL are unknown.
+type filterTruth int8
+
+const (
+	truthFalse filterTruth = iota
+	truthTrue
+	truthUnknown
+)
+
+// evalFilter evaluates the tree against an item whose fields are read with
+// get, with the three-valued logic of the SQL filterClause produces: a
+// comparison against a NULL or missing field is unknown, NOT keeps unknown
+// unknown and only true matches. The second result is false when the node
+// was dropped because it only references fields that are not allowed,
+// mirroring filterClause; checkFilterFields has already rejected those that
+// cannot be dropped.
+func evalFilter(get func(column string) (any, bool), e *FilterExpr, allowed map[string]string, sep string) (filterTruth, bool) {
+	if e == nil {
+		return truthTrue, false
+	}
+
+	if e.Comparison != nil {
+		if _, ok := allowed[e.Comparison.Field]; !ok {
+			return truthTrue, false
+		}
+		if op := e.Comparison.Op; op == ISNULL || op == NOTNULL {
+			if _, ok := nullFlag(e.Comparison.Value); !ok {
+				// malformed, comparisonClause skips it
+				return truthTrue, false
+			}
+		}
+		field, ok := get(e.Comparison.Field)
+		switch {
+		case (!ok || isNull(field)) && !nullAware(e.Comparison.Op):
+			return truthUnknown, true
+		case ok && compareWith(field, e.Comparison.Value, e.Comparison.Op, sep):
+			return truthTrue, true
+		}
+		return truthFalse, true
+	}
+
+	var (
+		present = false
+		unknown = false
+	)
+	for _, child := range e.Children {
+		truth, ok := evalFilter(get, child, allowed, sep)
+		if !ok {
+			continue
+		}
+		present = true
+
+		switch e.Op {
+		case LogicAnd:
+			if truth == truthFalse {
+				return truthFalse, true
+			}
+		case LogicOr:
+			if truth == truthTrue {
+				return truthTrue, true
+			}
+		case LogicNot:
+			switch truth {
+			case truthTrue:
+				return truthFalse, true
+			case truthFalse:
+				return truthTrue, true
+			}
+			return truthUnknown, true
+		}
+		unknown = unknown || truth == truthUnknown
+	}
+
+	switch {
+	case !present:
+		return truthTrue, false
+	case unknown:
+		return truthUnknown, true
+	case e.Op == LogicAnd:
+		return truthTrue, true
+	}
+	return truthFalse, true
+}
+
+// nullAware reports whether comparisonClause renders op so that NULL
+// columns give a definite result: the null checks, and ne and nin, which
+// match NULL explicitly.
+func nullAware(op ComparisonOp) bool {
+	switch op {
+	case ISNULL, NOTNULL, NE, NIN:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,276 @@
+// Package slicerhttp serves paginated endpoints over net/http. Its handlers
+// parse the query string, run QueryPage or SlicePage under the request
+// context and write the page as JSON with RFC 8288 Link and X-Total-Count
+// headers, mapping errors to the status codes slicer attaches to them.
+// ExportHandler streams whole results as CSV, NDJSON, JSON or XLSX downloads.
+package slicerhttp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/godev90/orm"
+	"github.com/godev90/slicer"
+	"github.com/godev90/validator/faults"
+)
+
+// Options configures the handlers and the middleware. The zero value parses
+// like slicer.ParseOpts.
+type Options struct {
+	// Parser parses the query string. Nil uses the defaults.
+	Parser *slicer.Parser
+
+	// Strict rejects invalid parameters with 400 Bad Request and the list
+	// of slicer.ParamErrors instead of ignoring them.
+	Strict bool
+
+	// Allowed lists the fields strict parsing accepts in Middleware, which
+	// requires it in strict mode. The handlers use the paginator's allowed
+	// fields instead.
+	Allowed map[string]string
+
+	// FormatParam names the query parameter choosing the format of
+	// ExportHandler responses, ahead of the Accept header. Defaults to
+	// "format".
+	FormatParam string
+}
+
+type ctxKey struct{}
+
+func (o Options) parser() *slicer.Parser {
+	if o.Parser != nil {
+		return o.Parser
+	}
+	return &slicer.Parser{}
+}
+
+func (o Options) formatParam() string {
+	if o.FormatParam != "" {
+		return o.FormatParam
+	}
+	return "format"
+}
+
+// parse reads the query options of r. Options stored by Middleware are
+// used as they are, but in strict mode the request is still checked against
+// allowed, which may be narrower than the fields Middleware accepted.
+func (o Options) parse(r *http.Request, allowed map[string]string) (slicer.QueryOptions, error) {
+	values := r.URL.Query()
+	values.Del(o.formatParam())
+
+	opts, stored := OptionsFrom(r.Context())
+	if !o.Strict {
+		if !stored {
+			opts = o.parser().Parse(values)
+		}
+		return opts, nil
+	}
+
+	parsed, err := o.parser().ParseStrict(values, allowed)
+	if !stored {
+		opts = parsed
+	}
+	return opts, err
+}
+
+// Handler serves pages of the paginator returned by newPaginator. A new
+// paginator is requested for every request because paginators hold the
+// items of the page being built.
+func Handler[T orm.Tabler](newPaginator func(r *http.Request) slicer.Paginator[T], options Options) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paginator := newPaginator(r)
+		opts, err := options.parse(r, paginator.AllowedFields())
+		if err != nil {
+			writePage(w, r, options.parser(), slicer.ErrorPage(err, opts))
+			return
+		}
+		data, _ := slicer.QueryPageContext(r.Context(), paginator, opts)
+		writePage(w, r, options.parser(), data)
+	})
+}
+
+// SliceHandler is Handler for slice paginators.
+func SliceHandler[T any](newPaginator func(r *http.Request) *slicer.SlicePaginator[T], options Options) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paginator := newPaginator(r)
+		opts, err := options.parse(r, paginator.AllowedFields())
+		if err != nil {
+			writePage(w, r, options.parser(), slicer.ErrorPage(err, opts))
+			return
+		}
+		data, _ := slicer.SlicePage(paginator, opts)
+		writePage(w, r, options.parser(), data)
+	})
+}
+
+// ExportHandler streams every row matching the request as CSV, NDJSON, a
+// JSON array or an XLSX workbook (see slicer.ExportTo), chosen by the format
+// parameter or else the Accept header. The response is offered as a
+// download named after the model's table. Errors raised before any row was
+// sent are answered like Handler errors; later ones can only cut the
+// download short.
+func ExportHandler[T orm.Tabler](newPaginator func(r *http.Request) slicer.Paginator[T], options Options) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		format, err := exportFormat(r, options.formatParam())
+		if err != nil {
+			writePage(w, r, options.parser(), errorPage(err, slicer.QueryOptions{}))
+			return
+		}
+
+		paginator := newPaginator(r)
+		opts, err := options.parse(r, paginator.AllowedFields())
+		if err != nil {
+			writePage(w, r, options.parser(), slicer.ErrorPage(err, opts))
+			return
+		}
+
+		out := &trackingWriter{w: w}
+		ew, _ := slicer.NewExportWriter(out, format)
+		w.Header().Set("Content-Type", format.ContentType())
+		w.Header().Set("Content-Disposition", `attachment; filename="`+paginator.Model().TableName()+"."+string(format)+`"`)
+
+		err = slicer.ExportTo(r.Context(), ew, paginator, opts)
+		if err == nil {
+			err = ew.Close()
+		}
+		if err != nil && !out.wrote {
+			w.Header().Del("Content-Disposition")
+			writePage(w, r, options.parser(), errorPage(err, opts))
+		}
+	})
+}
+
+// exportFormat returns the format requested by r.
+func exportFormat(r *http.Request, param string) (slicer.ExportFormat, error) {
+	if name := r.URL.Query().Get(param); name != "" {
+		return slicer.ParseExportFormat(name)
+	}
+	return slicer.NegotiateExportFormat(r.Header.Get("Accept"))
+}
+
+// errorPage wraps a plain error into an error page with its status.
+func errorPage(err error, opts slicer.QueryOptions) slicer.PageData {
+	return slicer.ErrorPage(faults.New(err, &faults.ErrAttr{Code: faults.ErrCode(StatusOf(err))}), opts)
+}
+
+// trackingWriter records whether anything reached the client.
+type trackingWriter struct {
+	w     http.ResponseWriter
+	wrote bool
+}
+
+func (t *trackingWriter) Write(p []byte) (int, error) {
+	t.wrote = true
+	return t.w.Write(p)
+}
+
+// Middleware parses the query options of each request and stores them in
+// its context, where OptionsFrom reads them back. Requests with invalid
+// parameters are answered with 400 Bad Request in strict mode, which needs
+// options.Allowed; Middleware panics without it. The handlers of this
+// package use stored options instead of parsing again.
+func Middleware(options Options) func(http.Handler) http.Handler {
+	if options.Strict && options.Allowed == nil {
+		panic("slicerhttp: strict Middleware needs Options.Allowed")
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			opts, err := options.parse(r, options.Allowed)
+			if err != nil {
+				writePage(w, r, options.parser(), slicer.ErrorPage(err, opts))
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), ctxKey{}, opts)))
+		})
+	}
+}
+
+// OptionsFrom returns the query options stored by Middleware.
+func OptionsFrom(ctx context.Context) (slicer.QueryOptions, bool) {
+	opts, ok := ctx.Value(ctxKey{}).(slicer.QueryOptions)
+	return opts, ok
+}
+
+// WritePage writes data as the JSON response to r, for handlers that run
+// the paginator themselves. Successful pages get Link and X-Total-Count
+// headers; error pages get the status of their LastError.
+func WritePage(w http.ResponseWriter, r *http.Request, data slicer.PageData) {
+	writePage(w, r, &slicer.Parser{}, data)
+}
+
+func writePage(w http.ResponseWriter, r *http.Request, parser *slicer.Parser, data slicer.PageData) {
+	status := http.StatusOK
+	if data.LastError != nil {
+		status = StatusOf(data.LastError)
+	} else {
+		data = parser.WithLinks(data, r.URL)
+		if link := LinkHeader(data.Links); link != "" {
+			w.Header().Set("Link", link)
+		}
+		if data.Total >= 0 {
+			w.Header().Set("X-Total-Count", strconv.FormatInt(data.Total, 10))
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(data)
+}
+
+// StatusOf returns the HTTP status for err: the code of errors carrying one
+// (faults errors and slicer.ParamErrors), 400 for the slicer errors caused
+// by the request, 406 for unsupported export formats and 500 otherwise.
+func StatusOf(err error) int {
+	var coded interface{ Code() faults.ErrCode }
+	if errors.As(err, &coded) {
+		if code := int(coded.Code()); code >= 400 && code <= 599 {
+			return code
+		}
+	}
+	if errors.Is(err, slicer.ErrUnsupportedFormat) {
+		return http.StatusNotAcceptable
+	}
+	for _, target := range clientErrors {
+		if errors.Is(err, target) {
+			return http.StatusBadRequest
+		}
+	}
+	return http.StatusInternalServerError
+}
+
+// clientErrors are the slicer errors caused by invalid requests.
+var clientErrors = []error{
+	slicer.ErrLimitExceeded,
+	slicer.ErrInvalidCursor,
+	slicer.ErrNullableCursorField,
+	slicer.ErrUnsupportedOperator,
+	slicer.ErrRegexDisabled,
+	slicer.ErrInvalidRegex,
+	slicer.ErrInvalidODataOption,
+	slicer.ErrFilterField,
+}
+
+// LinkHeader renders links as an RFC 8288 Link header value, or an empty
+// string when there are none.
+func LinkHeader(links *slicer.PageLinks) string {
+	if links == nil {
+		return ""
+	}
+
+	var parts []string
+	for _, link := range []struct{ rel, url string }{
+		{"first", links.First},
+		{"prev", links.Prev},
+		{"next", links.Next},
+		{"last", links.Last},
+	} {
+		if link.url != "" {
+			parts = append(parts, "<"+link.url+`>; rel="`+link.rel+`"`)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
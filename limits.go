@@ -0,0 +1,148 @@
+package slicer
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrLimitExceeded error = errors.New("slicer: query exceeds limits")
+)
+
+type (
+	// LimitMode selects what happens to query options exceeding Limits.
+	LimitMode string
+
+	// Limits guards paginators against expensive queries. Zero fields are
+	// not enforced. In LimitClamp mode oversized values are reduced to the
+	// maximum: limit and page are lowered, sort and search field lists are
+	// truncated and keywords are shortened. Filters and comparisons are
+	// never dropped, since that would widen the result, so exceeding
+	// MaxFilters or MaxComparisons is rejected in both modes.
+	Limits struct {
+		Mode LimitMode
+
+		// MaxLimit caps the page size. Requests without a limit count
+		// as exceeding it.
+		MaxLimit int
+
+		// MaxPage caps the page depth reached with offset pagination.
+		MaxPage int
+
+		// MaxFilters caps plain filters plus search_and fields.
+		MaxFilters int
+
+		// MaxComparisons caps comparisons, including those nested in the
+		// filter expression.
+		MaxComparisons int
+
+		// MaxSearchFields caps the explicit fields of a search.
+		MaxSearchFields int
+
+		// MaxSortFields caps the sort fields.
+		MaxSortFields int
+
+		// MaxKeywordLength caps the length, in characters, of search
+		// keywords.
+		MaxKeywordLength int
+
+		// MaxDownloadRows caps the rows returned by DownloadPage.
+		MaxDownloadRows int
+	}
+)
+
+const (
+	// Limit mode constants. The zero mode clamps.
+	LimitClamp  LimitMode = "clamp"
+	LimitReject LimitMode = "reject"
+)
+
+// apply enforces the limits on opts and returns the options to run. The
+// caller's options are not modified.
+func (l Limits) apply(opts QueryOptions) (QueryOptions, error) {
+	reject := l.Mode == LimitReject
+	exceeded := func(what string, max int) error {
+		return fmt.Errorf("%w: %s exceeds %d", ErrLimitExceeded, what, max)
+	}
+
+	if l.MaxLimit > 0 && (opts.Limit <= 0 || opts.Limit > l.MaxLimit) {
+		if reject {
+			return opts, exceeded("limit", l.MaxLimit)
+		}
+		opts.Limit = l.MaxLimit
+		opts.Offset = (opts.Page - 1) * opts.Limit
+	}
+
+	if l.MaxPage > 0 && opts.Cursor == nil {
+		page := opts.Page
+		if opts.Limit > 0 && opts.Offset/opts.Limit+1 > page {
+			page = opts.Offset/opts.Limit + 1
+		}
+		if page > l.MaxPage {
+			if reject {
+				return opts, exceeded("page", l.MaxPage)
+			}
+			opts.Page = l.MaxPage
+			opts.Offset = (opts.Page - 1) * opts.Limit
+		}
+	}
+
+	if l.MaxFilters > 0 {
+		count := len(opts.Filters)
+		if opts.SearchAnd != nil {
+			count += len(opts.SearchAnd.Fields)
+		}
+		if count > l.MaxFilters {
+			return opts, exceeded("filter count", l.MaxFilters)
+		}
+	}
+
+	if l.MaxComparisons > 0 {
+		count := len(opts.Comparisons)
+		opts.Filter.each(func(ComparisonFilter) { count++ })
+		if count > l.MaxComparisons {
+			return opts, exceeded("comparison count", l.MaxComparisons)
+		}
+	}
+
+	if l.MaxSortFields > 0 && len(opts.Sort) > l.MaxSortFields {
+		if reject {
+			return opts, exceeded("sort field count", l.MaxSortFields)
+		}
+		opts.Sort = opts.Sort[:l.MaxSortFields:l.MaxSortFields]
+	}
+
+	if opts.Search != nil {
+		search := *opts.Search
+		if l.MaxSearchFields > 0 && len(search.Fields) > l.MaxSearchFields {
+			if reject {
+				return opts, exceeded("search field count", l.MaxSearchFields)
+			}
+			search.Fields = search.Fields[:l.MaxSearchFields:l.MaxSearchFields]
+		}
+		if l.MaxKeywordLength > 0 && len([]rune(search.Keyword)) > l.MaxKeywordLength {
+			if reject {
+				return opts, exceeded("keyword length", l.MaxKeywordLength)
+			}
+			search.Keyword = string([]rune(search.Keyword)[:l.MaxKeywordLength])
+		}
+		opts.Search = &search
+	}
+
+	if opts.SearchAnd != nil && l.MaxKeywordLength > 0 {
+		fields := make([]*SearchField, len(opts.SearchAnd.Fields))
+		for i, f := range opts.SearchAnd.Fields {
+			field := *f
+			if len([]rune(field.Keyword)) > l.MaxKeywordLength {
+				if reject {
+					return opts, exceeded("keyword length", l.MaxKeywordLength)
+				}
+				field.Keyword = string([]rune(field.Keyword)[:l.MaxKeywordLength])
+			}
+			fields[i] = &field
+		}
+		opts.SearchAnd = &SearchQueryAnd{Fields: fields}
+	}
+
+	return opts, nil
+}
@@ -0,0 +1,118 @@
+package slicer
+
+import (
+	"net/url"
+	"reflect"
+	"strconv"
+)
+
+// PageLinks holds navigation links derived from the request URL. Links that
+// do not apply to the page (no next page, unknown last page, ...) are empty.
+type PageLinks struct {
+	Self  string `json:"self,omitempty"`
+	Next  string `json:"next,omitempty"`
+	Prev  string `json:"prev,omitempty"`
+	First string `json:"first,omitempty"`
+	Last  string `json:"last,omitempty"`
+}
+
+// withMeta fills the derived metadata of a page read at opts.Offset: total
+// pages, next/prev flags and the 1-based indices of the first and last item.
+// From and To stay zero in cursor mode, where the position is unknown.
+func withMeta(data PageData, opts QueryOptions) PageData {
+	switch {
+	case data.Total < 0:
+		data.TotalPages = -1
+	case data.Total == 0:
+		data.TotalPages = 0
+	case data.Limit <= 0:
+		data.TotalPages = 1
+	default:
+		data.TotalPages = (data.Total + int64(data.Limit) - 1) / int64(data.Limit)
+	}
+
+	data.HasNext = data.HasMore
+	if opts.Cursor != nil {
+		data.HasPrev = data.PrevCursor != ""
+		return data
+	}
+	data.HasPrev = opts.Offset > 0
+
+	if v := reflect.ValueOf(data.Items); v.Kind() == reflect.Slice && v.Len() > 0 {
+		data.From = int64(opts.Offset) + 1
+		data.To = int64(opts.Offset + v.Len())
+	}
+	return data
+}
+
+// WithLinks returns the page with Links built from requestURL, the URL of
+// the request that produced it. The links keep every query parameter of the
+// request and only change the page number or, in cursor mode, the cursor.
+func (d PageData) WithLinks(requestURL *url.URL) PageData {
+	return defaultParser().WithLinks(d, requestURL)
+}
+
+// WithLinks is PageData.WithLinks using the parser's parameter names.
+func (p *Parser) WithLinks(d PageData, requestURL *url.URL) PageData {
+	if requestURL == nil {
+		return d
+	}
+
+	var (
+		names = p.params()
+		query = requestURL.Query()
+		links = &PageLinks{Self: requestURL.String()}
+	)
+
+	link := func(change func(url.Values)) string {
+		values := url.Values{}
+		for key, val := range query {
+			values[key] = val
+		}
+		change(values)
+
+		u := *requestURL
+		u.RawQuery = values.Encode()
+		return u.String()
+	}
+
+	_, after := query[names.Cursor]
+	_, before := query[names.Before]
+	if after || before || d.NextCursor != "" || d.PrevCursor != "" {
+		links.First = link(func(v url.Values) {
+			v.Set(names.Cursor, "")
+			v.Del(names.Before)
+		})
+		if d.NextCursor != "" {
+			links.Next = link(func(v url.Values) {
+				v.Set(names.Cursor, d.NextCursor)
+				v.Del(names.Before)
+			})
+		}
+		if d.PrevCursor != "" {
+			links.Prev = link(func(v url.Values) {
+				v.Set(names.Before, d.PrevCursor)
+				v.Del(names.Cursor)
+			})
+		}
+		d.Links = links
+		return d
+	}
+
+	page := func(n int64) func(url.Values) {
+		return func(v url.Values) { v.Set(names.Page, strconv.FormatInt(n, 10)) }
+	}
+	links.First = link(page(1))
+	if d.HasNext {
+		links.Next = link(page(int64(d.Page) + 1))
+	}
+	if d.HasPrev && d.Page > 1 {
+		links.Prev = link(page(int64(d.Page) - 1))
+	}
+	// capped and estimated totals cannot tell where the last page is
+	if d.TotalPages > 0 && !d.TotalCapped && d.CountStrategy != CountEstimated {
+		links.Last = link(page(d.TotalPages))
+	}
+	d.Links = links
+	return d
+}
@@ -0,0 +1,559 @@
+package slicer
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrInvalidODataOption error = errors.New("slicer: invalid odata query option")
+)
+
+// odataOperators maps OData comparison operators to ComparisonOp.
+var odataOperators = map[string]ComparisonOp{
+	"eq": EQ,
+	"ne": NE,
+	"gt": GT,
+	"ge": GTE,
+	"lt": LT,
+	"le": LTE,
+	"in": IN,
+}
+
+// odataFlipped mirrors an operator for `literal op field` comparisons.
+var odataFlipped = map[ComparisonOp]ComparisonOp{
+	EQ: EQ, NE: NE, GT: LT, GTE: LTE, LT: GT, LTE: GTE,
+}
+
+// ODataResponse is the OData JSON shape of a page. Count is only set when the
+// request asked for `$count=true`; NextLink points at the following page and
+// is empty on the last one.
+type ODataResponse struct {
+	Count    *int64 `json:"@odata.count,omitempty"`
+	NextLink string `json:"@odata.nextLink,omitempty"`
+	Value    any    `json:"value"`
+}
+
+// ParseOData maps OData system query options into QueryOptions:
+//
+//	$filter=status eq 'open' and (priority gt 3 or contains(title,'urgent'))
+//	$orderby=created_at desc,id
+//	$top=20&$skip=40&$select=id,title
+//
+// $filter supports and, or, not, parentheses, the eq, ne, gt, ge, lt, le and
+// in operators and the contains, startswith and endswith functions; `eq null`
+// and `ne null` become isnull and notnull. $search needs the fields it spans
+// and returns ErrInvalidODataOption here; use ParseODataFor. $count only
+// affects the response, see NewODataResponse. Parameters without a `$` prefix
+// are handled by ParseOpts. Malformed $filter values return a *SyntaxError,
+// malformed numbers ErrInvalidODataOption. $skip sets Offset and the Page
+// containing it.
+func ParseOData(values url.Values) (QueryOptions, error) {
+	return defaultParser().ParseOData(values)
+}
+
+// ParseODataFor is ParseOData for the model T: $search spans the allowed
+// fields of T holding strings, the only columns a LIKE can be applied to on
+// every driver. A nil p uses the default settings.
+func ParseODataFor[T any](p *Parser, values url.Values, allowed map[string]string) (QueryOptions, error) {
+	if p == nil {
+		p = defaultParser()
+	}
+	opts, err := p.parseOData(values)
+	if err != nil || opts.Search == nil || len(opts.Search.Fields) > 0 {
+		return opts, err
+	}
+
+	modelType := reflect.TypeFor[T]()
+	for modelType.Kind() == reflect.Ptr {
+		modelType = modelType.Elem()
+	}
+	for field := range allowed {
+		if modelType.Kind() == reflect.Struct && isStringField(modelType, field) {
+			opts.Search.Fields = append(opts.Search.Fields, field)
+		}
+	}
+	sort.Strings(opts.Search.Fields)
+	return opts, nil
+}
+
+// isStringField reports whether the struct field matching name, as
+// findFieldByColumn resolves it, holds a string.
+func isStringField(modelType reflect.Type, name string) bool {
+	i := structFieldsOf(modelType).index(name)
+	if i < 0 {
+		return false
+	}
+	t := modelType.Field(i).Type
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.String
+}
+
+// ParseOData is the package ParseOData using the parser's settings. $top is
+// clamped to MaxLimit; operators the parser does not accept return
+// ErrUnsupportedOperator.
+func (p *Parser) ParseOData(values url.Values) (QueryOptions, error) {
+	opts, err := p.parseOData(values)
+	if err == nil && strings.TrimSpace(values.Get("$search")) != "" {
+		// a keyword without fields would match nothing in SlicePage and
+		// everything in QueryPage
+		err = fmt.Errorf("%w: $search needs the fields it spans, use ParseODataFor", ErrInvalidODataOption)
+	}
+	return opts, err
+}
+
+// parseOData parses the options shared by ParseOData and ParseODataFor.
+func (p *Parser) parseOData(values url.Values) (QueryOptions, error) {
+	rest := url.Values{}
+	for key, val := range values {
+		if !strings.HasPrefix(key, "$") {
+			rest[key] = val
+		}
+	}
+	opts := p.Parse(rest)
+
+	if top := values.Get("$top"); top != "" {
+		limit, err := strconv.Atoi(top)
+		if err != nil || limit <= 0 {
+			return opts, fmt.Errorf("%w: $top=%q", ErrInvalidODataOption, top)
+		}
+		if p.MaxLimit > 0 && limit > p.MaxLimit {
+			limit = p.MaxLimit
+		}
+		opts.Limit = limit
+	}
+	opts.Offset = (opts.Page - 1) * opts.Limit
+
+	if skip := values.Get("$skip"); skip != "" {
+		offset, err := strconv.Atoi(skip)
+		if err != nil || offset < 0 {
+			return opts, fmt.Errorf("%w: $skip=%q", ErrInvalidODataOption, skip)
+		}
+		opts.Offset = offset
+		opts.Page = offset/opts.Limit + 1
+	}
+
+	if orderby := values.Get("$orderby"); orderby != "" {
+		opts.Sort = nil
+		for _, item := range strings.Split(orderby, ",") {
+			parts := strings.Fields(item)
+			if len(parts) == 0 || len(parts) > 2 {
+				return opts, fmt.Errorf("%w: $orderby=%q", ErrInvalidODataOption, orderby)
+			}
+			desc := false
+			if len(parts) == 2 {
+				switch strings.ToLower(parts[1]) {
+				case "asc":
+				case "desc":
+					desc = true
+				default:
+					return opts, fmt.Errorf("%w: $orderby=%q", ErrInvalidODataOption, orderby)
+				}
+			}
+			opts.Sort = append(opts.Sort, SortField{Field: parts[0], Desc: desc})
+		}
+	}
+
+	if sel := values.Get("$select"); sel != "" && strings.TrimSpace(sel) != "*" {
+		opts.Select = nil
+		for _, field := range strings.Split(sel, ",") {
+			if field = strings.TrimSpace(field); field != "" {
+				opts.Select = append(opts.Select, field)
+			}
+		}
+	}
+
+	if search := strings.TrimSpace(values.Get("$search")); search != "" {
+		if len(search) > 1 && search[0] == '"' && search[len(search)-1] == '"' {
+			search = search[1 : len(search)-1]
+		}
+		opts.Search = &SearchQuery{Keyword: search}
+	}
+
+	if filter := values.Get("$filter"); strings.TrimSpace(filter) != "" {
+		op := &odataParser{input: filter, sep: p.separator()}
+		expr, err := op.parseOr()
+		if err != nil {
+			return opts, err
+		}
+		if tok := op.next(); tok.kind != odataEOF {
+			return opts, op.errorAt(tok, "unexpected %q", tok.text)
+		}
+		if err := p.checkOperators(expr); err != nil {
+			return opts, err
+		}
+		p.normalizeExpr(expr)
+
+		if opts.Filter != nil {
+			expr = And(opts.Filter, expr)
+		}
+		opts.Filter = expr
+	}
+	return opts, nil
+}
+
+// NewODataResponse wraps page in the OData response shape. requestURL is the
+// URL the page was requested with; it decides whether `@odata.count` is
+// included and is the base of `@odata.nextLink`. In cursor mode the next link
+// carries the page's NextCursor instead of a $skip.
+func NewODataResponse(page PageData, requestURL *url.URL) ODataResponse {
+	resp := ODataResponse{Value: page.Items}
+	if requestURL == nil {
+		return resp
+	}
+
+	query := requestURL.Query()
+	if count, _ := strconv.ParseBool(query.Get("$count")); count {
+		total := page.Total
+		resp.Count = &total
+	}
+
+	next := url.Values{}
+	for key, val := range query {
+		next[key] = val
+	}
+
+	switch {
+	case page.NextCursor != "":
+		next.Set("cursor", page.NextCursor)
+		next.Del("before")
+	case page.Limit > 0:
+		skip := (page.Page - 1) * page.Limit
+		if s, err := strconv.Atoi(query.Get("$skip")); err == nil && s >= 0 {
+			skip = s
+		}
+		if page.Total < 0 && !page.HasNext || page.Total >= 0 && int64(skip+page.Limit) >= page.Total {
+			return resp
+		}
+		next.Set("$skip", strconv.Itoa(skip+page.Limit))
+		next.Set("$top", strconv.Itoa(page.Limit))
+		next.Del("page")
+	default:
+		return resp
+	}
+
+	link := *requestURL
+	// OData consumers expect literal `$` in option names.
+	link.RawQuery = strings.ReplaceAll(next.Encode(), "%24", "$")
+	resp.NextLink = link.String()
+	return resp
+}
+
+type odataTokenKind int
+
+const (
+	odataEOF odataTokenKind = iota
+	odataIdent
+	odataString
+	odataLiteral
+	odataPunct
+)
+
+type odataToken struct {
+	kind odataTokenKind
+	text string
+	pos  int
+}
+
+type odataParser struct {
+	input  string
+	pos    int
+	sep    string
+	depth  int
+	peeked *odataToken
+}
+
+func (p *odataParser) errorAt(tok odataToken, format string, args ...any) error {
+	return &SyntaxError{Input: p.input, Pos: tok.pos + 1, Msg: fmt.Sprintf(format, args...)}
+}
+
+func (p *odataParser) peek() odataToken {
+	if p.peeked == nil {
+		tok := p.scan()
+		p.peeked = &tok
+	}
+	return *p.peeked
+}
+
+func (p *odataParser) next() odataToken {
+	tok := p.peek()
+	p.peeked = nil
+	return tok
+}
+
+// keyword reports whether the next token is the (case-insensitive) keyword
+// and consumes it if so.
+func (p *odataParser) keyword(word string) bool {
+	if tok := p.peek(); tok.kind == odataIdent && strings.EqualFold(tok.text, word) {
+		p.next()
+		return true
+	}
+	return false
+}
+
+func (p *odataParser) expect(punct string) error {
+	tok := p.next()
+	if tok.kind != odataPunct || tok.text != punct {
+		if tok.kind == odataEOF {
+			return p.errorAt(tok, "expected %q, found end of input", punct)
+		}
+		return p.errorAt(tok, "expected %q, found %q", punct, tok.text)
+	}
+	return nil
+}
+
+// scan reads the next token. String literals are unquoted, a doubled quote
+// standing for a single one. Bare words starting with a digit or sign, and
+// true, false and null, are literals; other bare words are identifiers.
+func (p *odataParser) scan() odataToken {
+	for p.pos < len(p.input) && (p.input[p.pos] == ' ' || p.input[p.pos] == '\t') {
+		p.pos++
+	}
+	start := p.pos
+	if p.pos >= len(p.input) {
+		return odataToken{kind: odataEOF, pos: start}
+	}
+
+	c := p.input[p.pos]
+	switch {
+	case c == '(' || c == ')' || c == ',':
+		p.pos++
+		return odataToken{kind: odataPunct, text: string(c), pos: start}
+	case c == '\'':
+		p.pos++
+		var b strings.Builder
+		for p.pos < len(p.input) {
+			if p.input[p.pos] == '\'' {
+				if p.pos+1 < len(p.input) && p.input[p.pos+1] == '\'' {
+					b.WriteByte('\'')
+					p.pos += 2
+					continue
+				}
+				p.pos++
+				return odataToken{kind: odataString, text: b.String(), pos: start}
+			}
+			b.WriteByte(p.input[p.pos])
+			p.pos++
+		}
+		p.pos = len(p.input)
+		return odataToken{kind: odataPunct, text: "'", pos: start}
+	}
+
+	for p.pos < len(p.input) && !strings.ContainsRune(" \t(),'", rune(p.input[p.pos])) {
+		p.pos++
+	}
+	text := p.input[start:p.pos]
+	switch {
+	case c >= '0' && c <= '9' || c == '-' || c == '+',
+		text == "true" || text == "false" || text == "null":
+		return odataToken{kind: odataLiteral, text: text, pos: start}
+	}
+	return odataToken{kind: odataIdent, text: text, pos: start}
+}
+
+func (p *odataParser) parseOr() (*FilterExpr, error) {
+	return p.parseGroup(LogicOr, "or", p.parseAnd)
+}
+
+func (p *odataParser) parseAnd() (*FilterExpr, error) {
+	return p.parseGroup(LogicAnd, "and", p.parseUnary)
+}
+
+func (p *odataParser) parseGroup(op LogicOp, keyword string, next func() (*FilterExpr, error)) (*FilterExpr, error) {
+	first, err := next()
+	if err != nil {
+		return nil, err
+	}
+
+	children := []*FilterExpr{first}
+	for p.keyword(keyword) {
+		child, err := next()
+		if err != nil {
+			return nil, err
+		}
+		children = append(children, child)
+	}
+
+	if len(children) == 1 {
+		return first, nil
+	}
+	return &FilterExpr{Op: op, Children: children}, nil
+}
+
+func (p *odataParser) parseUnary() (*FilterExpr, error) {
+	// `not` and parentheses are the only ways to nest
+	tok := p.peek()
+	if tok.kind == odataPunct && tok.text == "(" || tok.kind == odataIdent && strings.EqualFold(tok.text, "not") {
+		if p.depth == maxFilterDepth {
+			return nil, p.errorAt(tok, "filter nested deeper than %d levels", maxFilterDepth)
+		}
+		p.depth++
+		defer func() { p.depth-- }()
+	}
+
+	if p.keyword("not") {
+		child, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return Not(child), nil
+	}
+
+	if tok := p.peek(); tok.kind == odataPunct && tok.text == "(" {
+		p.next()
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		return expr, p.expect(")")
+	}
+	return p.parseComparison()
+}
+
+func (p *odataParser) parseComparison() (*FilterExpr, error) {
+	left := p.next()
+	switch left.kind {
+	case odataEOF:
+		return nil, p.errorAt(left, "expected expression, found end of input")
+	case odataPunct:
+		if left.text == "'" {
+			return nil, p.errorAt(left, "unterminated string literal")
+		}
+		return nil, p.errorAt(left, "expected expression, found %q", left.text)
+	}
+
+	if left.kind == odataIdent {
+		if tok := p.peek(); tok.kind == odataPunct && tok.text == "(" {
+			return p.parseFunction(left)
+		}
+	}
+
+	opTok := p.next()
+	op, ok := odataOperators[strings.ToLower(opTok.text)]
+	if opTok.kind != odataIdent || !ok {
+		if opTok.kind == odataEOF {
+			return nil, p.errorAt(opTok, "expected comparison operator, found end of input")
+		}
+		return nil, p.errorAt(opTok, "expected comparison operator, found %q", opTok.text)
+	}
+
+	if op == IN {
+		if left.kind != odataIdent {
+			return nil, p.errorAt(left, "expected field name, found %q", left.text)
+		}
+		values, err := p.parseList()
+		if err != nil {
+			return nil, err
+		}
+		return Compare(left.text, IN, strings.Join(values, p.sep)), nil
+	}
+
+	right := p.next()
+	if right.kind == odataEOF {
+		return nil, p.errorAt(right, "expected operand, found end of input")
+	}
+	if right.kind == odataPunct {
+		return nil, p.errorAt(right, "expected operand, found %q", right.text)
+	}
+
+	field, value := left, right
+	if left.kind != odataIdent {
+		if right.kind != odataIdent {
+			return nil, p.errorAt(left, "comparison needs a field name")
+		}
+		field, value = right, left
+		op = odataFlipped[op]
+	} else if right.kind == odataIdent {
+		return nil, p.errorAt(right, "expected literal, found %q", right.text)
+	}
+
+	if value.kind == odataLiteral && value.text == "null" {
+		switch op {
+		case EQ:
+			return Compare(field.text, ISNULL, ""), nil
+		case NE:
+			return Compare(field.text, NOTNULL, ""), nil
+		default:
+			return nil, p.errorAt(opTok, "%s cannot compare with null", opTok.text)
+		}
+	}
+	return Compare(field.text, op, value.text), nil
+}
+
+// parseFunction handles contains, startswith and endswith calls.
+func (p *odataParser) parseFunction(name odataToken) (*FilterExpr, error) {
+	p.next() // (
+	args := make([]odataToken, 0, 2)
+	for {
+		tok := p.next()
+		if tok.kind != odataIdent && tok.kind != odataString && tok.kind != odataLiteral {
+			if tok.kind == odataEOF {
+				return nil, p.errorAt(tok, "expected argument, found end of input")
+			}
+			return nil, p.errorAt(tok, "expected argument, found %q", tok.text)
+		}
+		args = append(args, tok)
+		if sep := p.peek(); sep.kind == odataPunct && sep.text == "," {
+			p.next()
+			continue
+		}
+		if err := p.expect(")"); err != nil {
+			return nil, err
+		}
+		break
+	}
+
+	var prefix, suffix string
+	switch strings.ToLower(name.text) {
+	case "contains":
+		prefix, suffix = "%", "%"
+	case "startswith":
+		suffix = "%"
+	case "endswith":
+		prefix = "%"
+	default:
+		return nil, p.errorAt(name, "unsupported function %q", name.text)
+	}
+	if len(args) != 2 || args[0].kind != odataIdent || args[1].kind != odataString {
+		return nil, p.errorAt(name, "%s() takes a field and a string", name.text)
+	}
+	return Compare(args[0].text, LIKE, prefix+escapeLike(args[1].text)+suffix), nil
+}
+
+// parseList reads the parenthesised value list of an `in` comparison.
+func (p *odataParser) parseList() ([]string, error) {
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+	var values []string
+	for {
+		tok := p.next()
+		if tok.kind != odataString && tok.kind != odataLiteral {
+			if tok.kind == odataEOF {
+				return nil, p.errorAt(tok, "expected value, found end of input")
+			}
+			return nil, p.errorAt(tok, "expected value, found %q", tok.text)
+		}
+		// lists are joined with the separator, so values cannot hold it
+		if strings.Contains(tok.text, p.sep) {
+			return nil, p.errorAt(tok, "in values must not contain %q", p.sep)
+		}
+		values = append(values, tok.text)
+
+		sep := p.next()
+		if sep.kind == odataPunct && sep.text == "," {
+			continue
+		}
+		if sep.kind == odataPunct && sep.text == ")" {
+			return values, nil
+		}
+		return nil, p.errorAt(sep, "expected ',' or ')' in value list")
+	}
+}
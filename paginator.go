@@ -1,11 +1,14 @@
 package slicer
 
 import (
+	"context"
+	"database/sql/driver"
 	"net/url"
 	"reflect"
 	"regexp"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/godev90/validator/typedef"
@@ -14,7 +17,11 @@ import (
 type (
 	// QueryOptions represents pagination and filtering options that can be
 	// applied to a data source. It includes page/limit parameters, sorting
-	// configuration, search fields, filters and comparison filters.
+	// configuration, search fields, filters and comparison filters. Filter
+	// holds an optional boolean expression tree that is ANDed with the flat
+	// filters. Separator splits list values; empty means the package
+	// separator set by SetValueSeparator. Count overrides the paginator's
+	// count strategy for this query.
 	QueryOptions struct {
 		Page        int
 		Limit       int
@@ -26,6 +33,10 @@ type (
 		Select      []string
 		GroupBy     []string
 		Comparisons []ComparisonFilter
+		Cursor      *CursorQuery
+		Filter      *FilterExpr
+		Separator   string
+		Count       CountStrategy
 	}
 
 	// SortField defines a field to sort by and whether the order is
@@ -58,21 +69,103 @@ type (
 	// PageData is the standard response structure returned by paginator
 	// functions. It contains the resulting items (as arbitrary JSON-able
 	// data), the total count, and pagination metadata. LastError may be
-	// populated when an error occurs while building the page.
+	// populated when an error occurs while building the page. NextCursor
+	// and PrevCursor are only set in cursor mode. CountStrategy tells how
+	// Total was computed; a Total of -1 means it is unknown, and so is a
+	// TotalPages of -1. HasNext and HasPrev hold whatever the total, and
+	// From and To are the 1-based indices of the first and last item (zero
+	// for empty pages and in cursor mode). Links is set by WithLinks.
 	PageData struct {
-		LastError error `json:"error,omitempty"`
-		Items     any   `json:"items"`
-		Total     int64 `json:"total"`
-		Page      int   `json:"page"`
-		Limit     int   `json:"limit"`
+		LastError     error         `json:"error,omitempty"`
+		Items         any           `json:"items"`
+		Total         int64         `json:"total"`
+		Page          int           `json:"page"`
+		Limit         int           `json:"limit"`
+		NextCursor    string        `json:"next_cursor,omitempty"`
+		PrevCursor    string        `json:"prev_cursor,omitempty"`
+		CountStrategy CountStrategy `json:"count_strategy,omitempty"`
+		TotalCapped   bool          `json:"total_capped,omitempty"`
+		HasMore       bool          `json:"has_more,omitempty"`
+		TotalPages    int64         `json:"total_pages"`
+		HasNext       bool          `json:"has_next"`
+		HasPrev       bool          `json:"has_prev"`
+		From          int64         `json:"from"`
+		To            int64         `json:"to"`
+		Links         *PageLinks    `json:"links,omitempty"`
+	}
+
+	// Config holds optional pagination settings. A paginator exposes them by
+	// implementing Configurer.
+	Config struct {
+		// CursorKey is the allowed field used as the unique tiebreaker in
+		// cursor pagination. Defaults to "id".
+		CursorKey string
+
+		// CursorSecret is the key used to sign cursor tokens. Defaults to
+		// the package secret set by SetCursorSecret; cursor pages fail with
+		// ErrNoCursorSecret when neither is set.
+		CursorSecret []byte
+
+		// AllowRegex enables the regex comparison operator. It is off by
+		// default because arbitrary patterns can be expensive to evaluate.
+		AllowRegex bool
+
+		// Limits guards against oversized queries. The zero value
+		// enforces nothing.
+		Limits Limits
+
+		// Cache stores QueryPage results keyed by the model table, the
+		// canonical options and CacheScope. Nothing is cached unless
+		// CacheScope and PageTTL or CountTTL are set as well.
+		Cache PageCache
+
+		// CacheScope returns the part of the cache key that the options
+		// do not carry, such as the tenant an adapter's Where clauses
+		// restrict the query to. It is required: without it requests
+		// scoped differently would share cached pages.
+		CacheScope func(ctx context.Context) string
+
+		// PageTTL is how long whole pages are cached. Each caller gets
+		// its own copy of the cached items.
+		PageTTL time.Duration
+
+		// CountTTL is how long totals are cached. Pages of the same query
+		// share one cached total.
+		CountTTL time.Duration
+
+		// CountStrategy is the count strategy of queries that do not set
+		// QueryOptions.Count. Defaults to CountExact.
+		CountStrategy CountStrategy
+
+		// CountCap is the largest total CountCapped counts to. Defaults
+		// to 1000.
+		CountCap int
+
+		// CountTimeout bounds the count query. A count running longer
+		// reports a Total of -1 instead of failing the page. Defaults to
+		// 3 seconds; a negative value only applies the caller's context.
+		CountTimeout time.Duration
+
+		// ExportBatchSize is the number of rows ExportTo reads per query.
+		// Defaults to 1000.
+		ExportBatchSize int
+	}
+
+	// Configurer is implemented by paginators that customise Config.
+	// Paginators that do not implement it use the zero Config.
+	Configurer interface {
+		Config() Config
 	}
 
 	// ComparisonOp is the type for comparison operators used in
-	// ComparisonFilter (gt,gte,lt,lte,eq).
+	// ComparisonFilter (gt,gte,lt,lte,eq,ne,in,nin,between,isnull,notnull,
+	// like,ilike,prefix,suffix,regex).
 	ComparisonOp string
 
 	// ComparisonFilter represents a single comparison applied to a field
-	// (e.g. age[gt]=30).
+	// (e.g. age[gt]=30). List operators (in, nin, between) take values
+	// joined by the value separator; isnull and notnull take an optional
+	// boolean (`deleted_at[isnull]=false` equals `deleted_at[notnull]`).
 	ComparisonFilter struct {
 		Field string
 		Op    ComparisonOp
@@ -87,106 +180,89 @@ const (
 	LT  ComparisonOp = "lt"
 	LTE ComparisonOp = "lte"
 	EQ  ComparisonOp = "eq"
-)
 
-var valueSeparator = ","
+	NE      ComparisonOp = "ne"
+	IN      ComparisonOp = "in"
+	NIN     ComparisonOp = "nin"
+	BETWEEN ComparisonOp = "between"
+	ISNULL  ComparisonOp = "isnull"
+	NOTNULL ComparisonOp = "notnull"
+
+	// Pattern operator constants. LIKE and ILIKE take an SQL pattern with
+	// % and _ wildcards, PREFIX and SUFFIX a literal (case-insensitive)
+	// keyword and REGEX a regular expression. REGEX is rejected unless the
+	// paginator sets Config.AllowRegex.
+	LIKE   ComparisonOp = "like"
+	ILIKE  ComparisonOp = "ilike"
+	PREFIX ComparisonOp = "prefix"
+	SUFFIX ComparisonOp = "suffix"
+	REGEX  ComparisonOp = "regex"
+)
 
-func SetValueSeparator(separator string) {
-	valueSeparator = separator
+// comparisonOps lists every operator accepted in `field[op]=value`
+// parameters.
+var comparisonOps = map[ComparisonOp]bool{
+	GT: true, GTE: true, LT: true, LTE: true, EQ: true,
+	NE: true, IN: true, NIN: true, BETWEEN: true, ISNULL: true, NOTNULL: true,
+	LIKE: true, ILIKE: true, PREFIX: true, SUFFIX: true, REGEX: true,
 }
 
-// SetValueSeparator changes the separator used when parsing list-style
-// query parameters (default is a comma).
+var comparisonPattern = regexp.MustCompile(`^([a-zA-Z0-9_]+)\[([a-z]+)\]$`)
 
+// defaultSeparator splits list values unless SetValueSeparator changed it.
+const defaultSeparator = ","
 
-func ParseOpts(values url.Values) QueryOptions {
-	opts := QueryOptions{
-		Page:    1,
-		Limit:   10,
-		Filters: map[string]string{},
-	}
+// valueSeparator holds the separator set by SetValueSeparator.
+var valueSeparator atomic.Pointer[string]
 
-	if p := values.Get("page"); p != "" {
-		if page, _ := strconv.Atoi(p); page > 0 {
-			opts.Page = page
-		}
-	}
-	if l := values.Get("limit"); l != "" {
-		if limit, _ := strconv.Atoi(l); limit > 0 {
-			opts.Limit = limit
-		}
-	}
-	opts.Offset = (opts.Page - 1) * opts.Limit
+// timeLayouts lists the formats accepted for time values in comparisons.
+var timeLayouts = []string{
+	time.RFC3339,           // "2006-01-02T15:04:05Z07:00"
+	"2006-01-02T15:04:05Z", // "2023-01-01T00:00:00Z"
+	"2006-01-02 15:04:05",  // "2023-01-01 00:00:00"
+	"2006-01-02",           // "2023-01-01"
+}
 
-	if sort := values.Get("sort"); sort != "" {
-		fields := strings.Split(sort, valueSeparator)
-		for _, f := range fields {
-			desc := strings.HasPrefix(f, "-")
-			field := strings.TrimPrefix(f, "-")
-			opts.Sort = append(opts.Sort, SortField{Field: field, Desc: desc})
-		}
-	}
-	if fields := values.Get("search"); fields != "" {
-		if keyword := values.Get("keyword"); keyword != "" {
-			opts.Search = &SearchQuery{
-				Fields:  strings.Split(fields, valueSeparator),
-				Keyword: keyword,
-			}
-		}
-	}
-	if sel := values.Get("select"); sel != "" {
-		opts.Select = strings.Split(sel, valueSeparator)
-	}
-	if group := values.Get("group"); group != "" {
-		opts.GroupBy = strings.Split(group, valueSeparator)
-		// force selection equal to group. this prevent full group by only
-		opts.Select = strings.Split(group, valueSeparator)
+// SetValueSeparator changes the package separator used when parsing
+// list-style query parameters (default is a comma). It applies to every
+// Parser without its own Separator. The package-level parse functions
+// take a snapshot of it at the call.
+//
+// Deprecated: set Parser.Separator instead, which does not change the
+// behaviour of other services in the same binary.
+func SetValueSeparator(separator string) {
+	valueSeparator.Store(&separator)
 
-		for _, sort := range opts.Sort {
-			opts.GroupBy = append(opts.GroupBy, sort.Field)
-		}
+	parser := &Parser{}
+	if separator != defaultSeparator {
+		parser.Separator = separator
 	}
+	defaultParserRef.Store(parser)
+}
 
-	for key, val := range values {
-		if key == "page" || key == "limit" || key == "sort" || key == "search" || key == "keyword" || key == "select" || key == "group" {
-			continue
-		}
-		// Handle both searchAnd.field=keyword and search_and.field=keyword formats
-		if (strings.HasPrefix(key, "searchAnd.") || strings.HasPrefix(key, "search_and.")) && len(val) > 0 {
-			var fieldName string
-			if strings.HasPrefix(key, "searchAnd.") {
-				fieldName = strings.TrimPrefix(key, "searchAnd.")
-			} else {
-				fieldName = strings.TrimPrefix(key, "search_and.")
-			}
-			if fieldName != "" && val[0] != "" {
-				if opts.SearchAnd == nil {
-					opts.SearchAnd = &SearchQueryAnd{Fields: []*SearchField{}}
-				}
-				opts.SearchAnd.Fields = append(opts.SearchAnd.Fields, &SearchField{
-					Field:   fieldName,
-					Keyword: val[0],
-				})
-			}
-			continue
-		}
-		if matches := regexp.MustCompile(`^([a-zA-Z0-9_]+)\[(gt|gte|lt|lte|eq)\]$`).FindStringSubmatch(key); len(matches) == 3 {
-			opts.Comparisons = append(opts.Comparisons, ComparisonFilter{
-				Field: matches[1],
-				Op:    ComparisonOp(matches[2]),
-				Value: val[0],
-			})
-			continue
-		}
-		opts.Filters[key] = val[0]
+// packageSeparator returns the separator set by SetValueSeparator, or the
+// default comma.
+func packageSeparator() string {
+	if sep := valueSeparator.Load(); sep != nil && *sep != "" {
+		return *sep
 	}
-	return opts
+	return defaultSeparator
+}
+
+
+func ParseOpts(values url.Values) QueryOptions {
+	return defaultParser().Parse(values)
 }
 
 // ParseOpts parses URL query values into a QueryOptions struct. It supports
 // pagination parameters (page, limit), sorting, searching, selecting fields,
 // grouping and filter/comparison parameters. Comparison filters follow the
-// `field[op]=value` syntax where op is one of gt,gte,lt,lte,eq.
+// `field[op]=value` syntax where op is one of gt,gte,lt,lte,eq,ne,in,nin,
+// between,isnull,notnull,like,ilike,prefix,suffix,regex. The `filter`
+// parameter takes a boolean expression (see ParseFilterExpr); malformed
+// expressions are ignored. The presence of `cursor` or `before` (even empty)
+// switches to cursor pagination. ParseOpts uses the default Parser; build a
+// Parser to change the separator, limits or parameter names.
 
 
 func ErrorPage(err error, opts QueryOptions) PageData {
@@ -202,36 +278,87 @@ func ErrorPage(err error, opts QueryOptions) PageData {
 // ErrorPage returns a PageData populated with an error and empty results.
 // Useful for returning a consistent error response from pagination helpers.
 
+// separator returns the separator splitting list values of o.
+func (o QueryOptions) separator() string {
+	if o.Separator != "" {
+		return o.Separator
+	}
+	return packageSeparator()
+}
+
+// configOf returns the Config exposed by p, or the zero Config when p does
+// not implement Configurer.
+func configOf(p any) Config {
+	if c, ok := p.(Configurer); ok {
+		return c.Config()
+	}
+	return Config{}
+}
 
 // findFieldByColumn returns the struct field by matching the JSON tag or field name
 func findFieldByColumn(v reflect.Value, column string) reflect.Value {
 	if v.Kind() == reflect.Ptr {
 		v = v.Elem()
 	}
-	t := v.Type()
-	for i := 0; i < v.NumField(); i++ {
-		field := t.Field(i)
-		jsonTag := field.Tag.Get("json")
-		if jsonTag != "" && jsonTag != "-" {
-			jsonName := strings.Split(jsonTag, ",")[0]
-			if jsonName == column {
-				return v.Field(i)
-			}
-		}
-		if strings.EqualFold(field.Name, column) {
-			return v.Field(i)
-		}
+	if i := structFieldsOf(v.Type()).index(column); i >= 0 {
+		return v.Field(i)
 	}
 	return reflect.Value{}
 }
 
 // findFieldByColumn returns the reflect.Value for a field that matches the
 // given JSON tag or field name (case-insensitive). It handles pointer
-// receivers by dereferencing them.
+// receivers by dereferencing them. The match is resolved once per struct
+// type and column and cached.
 
 
 // compare is used for filtering values (uses ComparisonOp from external file)
 func compare(fieldVal interface{}, strVal string, op ComparisonOp) bool {
+	return compareWith(fieldVal, strVal, op, packageSeparator())
+}
+
+// compare evaluates a ComparisonFilter for a given field value. It supports
+// several typed inputs (strings, numeric typedefs, time values) and returns
+// true when the comparison holds.
+
+// compareWith is compare with list values (in, nin, between) split by sep.
+func compareWith(fieldVal interface{}, strVal string, op ComparisonOp, sep string) bool {
+	switch op {
+	case ISNULL, NOTNULL:
+		flag, ok := nullFlag(strVal)
+		return !ok || isNull(fieldVal) == (flag == (op == ISNULL))
+	case NE:
+		if isNull(fieldVal) {
+			return true
+		}
+		// types eq cannot compare match neither eq nor ne
+		return comparableValue(fieldVal) && !compare(fieldVal, strVal, EQ)
+	case IN, NIN:
+		if isNull(fieldVal) {
+			return op == NIN
+		}
+		found := false
+		for _, v := range strings.Split(strVal, sep) {
+			if compare(fieldVal, v, EQ) {
+				found = true
+				break
+			}
+		}
+		return found == (op == IN)
+	case BETWEEN:
+		bounds := strings.Split(strVal, sep)
+		return len(bounds) == 2 && compare(fieldVal, bounds[0], GTE) && compare(fieldVal, bounds[1], LTE)
+	case LIKE, ILIKE, PREFIX, SUFFIX, REGEX:
+		return matchPattern(fieldVal, strVal, op)
+	}
+
+	if rv := reflect.ValueOf(fieldVal); rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return false
+		}
+		fieldVal = rv.Elem().Interface()
+	}
+
 	switch v := fieldVal.(type) {
 	case string:
 		return compareString(v, strVal, op)
@@ -254,9 +381,68 @@ func compare(fieldVal interface{}, strVal string, op ComparisonOp) bool {
 	}
 }
 
-// compare evaluates a ComparisonFilter for a given field value. It supports
-// several typed inputs (strings, numeric typedefs, time values) and returns
-// true when the comparison holds.
+// isNull reports whether a field value represents SQL NULL: nil pointers,
+// empty typedef numbers, zero typedef dates and driver.Valuer values (such
+// as sql.NullString) yielding nil.
+func isNull(v any) bool {
+	switch x := v.(type) {
+	case nil:
+		return true
+	case typedef.Integer:
+		return x.String() == ""
+	case typedef.Float:
+		return x.String() == ""
+	case typedef.Date:
+		return x.IsZero()
+	case typedef.Datetime:
+		return x.IsZero()
+	case driver.Valuer:
+		rv := reflect.ValueOf(v)
+		if rv.Kind() == reflect.Ptr && rv.IsNil() {
+			return true
+		}
+		val, err := x.Value()
+		return err == nil && val == nil
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Map, reflect.Slice:
+		return rv.IsNil()
+	}
+	return false
+}
+
+// comparableValue reports whether compare supports the type of v, the
+// value behind a pointer included.
+func comparableValue(v any) bool {
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return false
+		}
+		v = rv.Elem().Interface()
+	}
+
+	switch v.(type) {
+	case string, typedef.Integer, typedef.Float, typedef.Date, typedef.Datetime,
+		int, int64, float32, float64, time.Time:
+		return true
+	}
+	return false
+}
+
+// nullFlag parses the value of an isnull/notnull comparison. An empty value
+// means true so that `deleted_at[isnull]=` reads naturally. The second
+// result is false for values that are not booleans, such as
+// `deleted_at[isnull]=maybe`; those comparisons are malformed and skipped,
+// and ParseStrict rejects them.
+func nullFlag(value string) (bool, bool) {
+	if value == "" {
+		return true, true
+	}
+	flag, err := strconv.ParseBool(value)
+	return flag, err == nil
+}
 
 
 // Sorting comparator (used in sort.SliceStable)
@@ -365,17 +551,9 @@ func compareFloat64(aStr, bStr string, op ComparisonOp) bool {
 
 
 func compareTime(a time.Time, bStr string, op ComparisonOp) bool {
-	// Try multiple time layouts to handle different formats
-	layouts := []string{
-		time.RFC3339,           // "2006-01-02T15:04:05Z07:00"
-		"2006-01-02T15:04:05Z", // "2023-01-01T00:00:00Z"
-		"2006-01-02 15:04:05",  // "2023-01-01 00:00:00"
-		"2006-01-02",           // "2023-01-01"
-	}
-
 	var b time.Time
 	var err error
-	for _, layout := range layouts {
+	for _, layout := range timeLayouts {
 		b, err = time.Parse(layout, bStr)
 		if err == nil {
 			break
@@ -515,15 +693,13 @@ func DefaultFilterByJson[T any]() map[string]string {
 		t = t.Elem()
 	}
 
-	fields := make(map[string]string)
-	for i := 0; i < t.NumField(); i++ {
-		f := t.Field(i)
-		jsonTag := f.Tag.Get("json")
-		if jsonTag != "" && jsonTag != "-" {
-			name := strings.Split(jsonTag, ",")[0]
-			fields[name] = name
+	cached := structFieldsOf(t)
+	fields := make(map[string]string, len(cached.names))
+	for i, name := range cached.names {
+		if cached.tagged[i] {
+			fields[cached.json[i]] = cached.json[i]
 		} else {
-			fields[strings.ToLower(f.Name)] = strings.ToLower(f.Name)
+			fields[strings.ToLower(name)] = strings.ToLower(name)
 		}
 	}
 	return fields
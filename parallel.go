@@ -0,0 +1,143 @@
+package slicer
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// The parallel helpers split their input across a pool of goroutines. A
+// worker count below 1 uses GOMAXPROCS. Results keep the order of the input.
+// The context passed to the callbacks is cancelled as soon as one of them
+// fails or ctx is done; the helpers then stop handing out elements and
+// return the first error, or ctx.Err(), along with no result.
+
+// ParallelFilter returns the elements of s for which keep returns true,
+// calling keep concurrently.
+func ParallelFilter[T any](ctx context.Context, s []T, workers int, keep func(context.Context, T) (bool, error)) ([]T, error) {
+	kept := make([]bool, len(s))
+	err := parallelEach(ctx, len(s), workers, func(ctx context.Context, i int) error {
+		ok, err := keep(ctx, s[i])
+		kept[i] = ok
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]T, 0, len(s))
+	for i, v := range s {
+		if kept[i] {
+			out = append(out, v)
+		}
+	}
+	return out, nil
+}
+
+// ParallelMap returns the result of fn for every element of s, calling fn
+// concurrently.
+func ParallelMap[T, U any](ctx context.Context, s []T, workers int, fn func(context.Context, T) (U, error)) ([]U, error) {
+	out := make([]U, len(s))
+	err := parallelEach(ctx, len(s), workers, func(ctx context.Context, i int) error {
+		v, err := fn(ctx, s[i])
+		out[i] = v
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ParallelReduce folds s into a single value. The input is cut into one
+// contiguous chunk per worker, each folded with fn starting from initial, and
+// the partial results are merged with combine in input order. initial must
+// therefore be an identity for combine (0 for sums, "" for concatenation)
+// and combine must be associative; it need not be commutative.
+func ParallelReduce[T, A any](ctx context.Context, s []T, workers int, initial A, fn func(A, T) (A, error), combine func(A, A) A) (A, error) {
+	workers = parallelWorkers(workers, len(s))
+	size := (len(s) + workers - 1) / workers
+	if size == 0 {
+		return initial, nil
+	}
+
+	partials := make([]A, (len(s)+size-1)/size)
+	err := parallelEach(ctx, len(partials), workers, func(ctx context.Context, chunk int) error {
+		acc := initial
+		for _, v := range s[chunk*size : min((chunk+1)*size, len(s))] {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			var err error
+			if acc, err = fn(acc, v); err != nil {
+				return err
+			}
+		}
+		partials[chunk] = acc
+		return nil
+	})
+	if err != nil {
+		var zero A
+		return zero, err
+	}
+
+	acc := partials[0]
+	for _, partial := range partials[1:] {
+		acc = combine(acc, partial)
+	}
+	return acc, nil
+}
+
+// parallelWorkers returns the size of the pool for n elements.
+func parallelWorkers(workers, n int) int {
+	if workers < 1 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	return max(min(workers, n), 1)
+}
+
+// parallelEach calls fn for every index below n on a pool of workers, which
+// take the next index as they become free. It returns the first error of fn,
+// or the error of ctx if it was done before every index was handed out.
+func parallelEach(ctx context.Context, n, workers int, fn func(ctx context.Context, i int) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		next     atomic.Int64
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+	)
+	fail := func(err error) {
+		once.Do(func() {
+			firstErr = err
+			cancel()
+		})
+	}
+
+	workers = parallelWorkers(workers, n)
+	wg.Add(workers)
+	for range workers {
+		go func() {
+			defer wg.Done()
+			for {
+				i := int(next.Add(1) - 1)
+				if i >= n {
+					return
+				}
+				if err := ctx.Err(); err != nil {
+					fail(err)
+					return
+				}
+				if err := fn(ctx, i); err != nil {
+					fail(err)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	return firstErr
+}
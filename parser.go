@@ -0,0 +1,353 @@
+package slicer
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+var (
+	ErrUnsupportedOperator error = errors.New("slicer: unsupported operator")
+)
+
+type (
+	// Parser turns URL query values into QueryOptions. Its zero value parses
+	// exactly like ParseOpts; set fields to override the defaults. A Parser
+	// is safe for concurrent use once configured, so services can keep one
+	// per API without touching package-level settings.
+	Parser struct {
+		// Separator splits list values (sort, select, in, between, ...).
+		// Defaults to the package separator set by SetValueSeparator.
+		Separator string
+
+		// DefaultLimit is used when the request has no valid limit.
+		// Defaults to 10.
+		DefaultLimit int
+
+		// MaxLimit caps the limit; larger values are clamped by Parse and
+		// rejected by ParseStrict. Zero means no maximum.
+		MaxLimit int
+
+		// Params renames the query parameters. Empty names keep the
+		// defaults.
+		Params ParamNames
+
+		// Operators restricts the accepted comparison operators. Nil
+		// accepts every operator.
+		Operators []ComparisonOp
+
+		// DateLayouts lists extra formats accepted for dates in
+		// comparisons. Matching values are rewritten to the standard
+		// "2006-01-02" or RFC 3339 form the paginators understand.
+		DateLayouts []string
+	}
+
+	// ParamNames holds the query parameter names read by a Parser.
+	ParamNames struct {
+		Page      string
+		Limit     string
+		Sort      string
+		Search    string
+		Keyword   string
+		Select    string
+		Group     string
+		Cursor    string
+		Before    string
+		Filter    string
+		Query     string // RSQL query, see ParseRSQL
+		SearchAnd string // prefix of `<prefix>.field=keyword` parameters
+	}
+)
+
+// defaultParserRef holds the parser backing ParseOpts, ParseOptsStrict,
+// ParseRSQL and ParseOData. SetValueSeparator replaces it.
+var defaultParserRef atomic.Pointer[Parser]
+
+// defaultParser returns the parser backing the package-level functions.
+func defaultParser() *Parser {
+	if p := defaultParserRef.Load(); p != nil {
+		return p
+	}
+	return &Parser{}
+}
+
+// defaultParams are the parameter names used when ParamNames leaves a name
+// empty. SearchAnd is empty because both `searchAnd.` and `search_and.` are
+// accepted by default.
+var defaultParams = ParamNames{
+	Page:    "page",
+	Limit:   "limit",
+	Sort:    "sort",
+	Search:  "search",
+	Keyword: "keyword",
+	Select:  "select",
+	Group:   "group",
+	Cursor:  "cursor",
+	Before:  "before",
+	Filter:  "filter",
+	Query:   "q",
+}
+
+func (p *Parser) separator() string {
+	if p.Separator != "" {
+		return p.Separator
+	}
+	return packageSeparator()
+}
+
+func (p *Parser) defaultLimit() int {
+	if p.DefaultLimit > 0 {
+		return p.DefaultLimit
+	}
+	return 10
+}
+
+// params returns the parameter names with defaults filled in.
+func (p *Parser) params() ParamNames {
+	names := p.Params
+	fill := func(name *string, def string) {
+		if *name == "" {
+			*name = def
+		}
+	}
+	fill(&names.Page, defaultParams.Page)
+	fill(&names.Limit, defaultParams.Limit)
+	fill(&names.Sort, defaultParams.Sort)
+	fill(&names.Search, defaultParams.Search)
+	fill(&names.Keyword, defaultParams.Keyword)
+	fill(&names.Select, defaultParams.Select)
+	fill(&names.Group, defaultParams.Group)
+	fill(&names.Cursor, defaultParams.Cursor)
+	fill(&names.Before, defaultParams.Before)
+	fill(&names.Filter, defaultParams.Filter)
+	fill(&names.Query, defaultParams.Query)
+	return names
+}
+
+// reserved reports whether key is one of the named parameters.
+func (names ParamNames) reserved(key string) bool {
+	switch key {
+	case names.Page, names.Limit, names.Sort, names.Search, names.Keyword, names.Select, names.Group, names.Cursor, names.Before, names.Filter:
+		return true
+	}
+	return false
+}
+
+// searchAndField returns the field of a `searchAnd.field` parameter.
+func (names ParamNames) searchAndField(key string) (string, bool) {
+	if names.SearchAnd != "" {
+		return strings.CutPrefix(key, names.SearchAnd+".")
+	}
+	if field, ok := strings.CutPrefix(key, "searchAnd."); ok {
+		return field, true
+	}
+	return strings.CutPrefix(key, "search_and.")
+}
+
+// allowsOperator reports whether op is a known operator the parser accepts.
+func (p *Parser) allowsOperator(op ComparisonOp) bool {
+	if !comparisonOps[op] {
+		return false
+	}
+	if p.Operators == nil {
+		return true
+	}
+	for _, allowed := range p.Operators {
+		if op == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+// checkOperators returns ErrUnsupportedOperator for the first comparison in
+// e that uses an operator the parser does not accept.
+func (p *Parser) checkOperators(e *FilterExpr) error {
+	var err error
+	e.each(func(cmp ComparisonFilter) {
+		if err == nil && !p.allowsOperator(cmp.Op) {
+			err = fmt.Errorf("%w: %q", ErrUnsupportedOperator, cmp.Op)
+		}
+	})
+	return err
+}
+
+// normalizeValue rewrites dates written in one of DateLayouts into a layout
+// of timeLayouts. Other values are returned unchanged.
+func (p *Parser) normalizeValue(op ComparisonOp, value string) string {
+	if len(p.DateLayouts) == 0 {
+		return value
+	}
+	switch op {
+	case LIKE, ILIKE, PREFIX, SUFFIX, REGEX, ISNULL, NOTNULL:
+		return value
+	}
+
+	sep := p.separator()
+	parts := strings.Split(value, sep)
+	for i, part := range parts {
+		if isTime(part) {
+			continue
+		}
+		for _, layout := range p.DateLayouts {
+			t, err := time.Parse(layout, part)
+			if err != nil {
+				continue
+			}
+			if t.Equal(time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())) {
+				parts[i] = t.Format("2006-01-02")
+			} else {
+				parts[i] = t.Format(time.RFC3339)
+			}
+			break
+		}
+	}
+	return strings.Join(parts, sep)
+}
+
+// normalizeExpr applies normalizeValue to every comparison in e.
+func (p *Parser) normalizeExpr(e *FilterExpr) {
+	if e == nil {
+		return
+	}
+	if e.Comparison != nil {
+		e.Comparison.Value = p.normalizeValue(e.Comparison.Op, e.Comparison.Value)
+	}
+	for _, child := range e.Children {
+		p.normalizeExpr(child)
+	}
+}
+
+// parseFilter parses a filter expression with the parser's separator and
+// operators.
+func (p *Parser) parseFilter(input string) (*FilterExpr, error) {
+	expr, err := parseFilterExpr(input, p.separator())
+	if err != nil {
+		return nil, err
+	}
+	if err := p.checkOperators(expr); err != nil {
+		return nil, err
+	}
+	p.normalizeExpr(expr)
+	return expr, nil
+}
+
+// Parse parses URL query values into QueryOptions the way ParseOpts does,
+// using the parser's settings. Invalid input is ignored; use ParseStrict to
+// report it.
+func (p *Parser) Parse(values url.Values) QueryOptions {
+	var (
+		names = p.params()
+		sep   = p.separator()
+	)
+
+	opts := QueryOptions{
+		Page:      1,
+		Limit:     p.defaultLimit(),
+		Filters:   map[string]string{},
+		Separator: p.Separator,
+	}
+
+	if v := values.Get(names.Page); v != "" {
+		if page, _ := strconv.Atoi(v); page > 0 {
+			opts.Page = page
+		}
+	}
+	if l := values.Get(names.Limit); l != "" {
+		if limit, _ := strconv.Atoi(l); limit > 0 {
+			opts.Limit = limit
+		}
+	}
+	if p.MaxLimit > 0 && opts.Limit > p.MaxLimit {
+		opts.Limit = p.MaxLimit
+	}
+	opts.Offset = (opts.Page - 1) * opts.Limit
+
+	if sort := values.Get(names.Sort); sort != "" {
+		fields := strings.Split(sort, sep)
+		for _, f := range fields {
+			desc := strings.HasPrefix(f, "-")
+			field := strings.TrimPrefix(f, "-")
+			opts.Sort = append(opts.Sort, SortField{Field: field, Desc: desc})
+		}
+	}
+	if fields := values.Get(names.Search); fields != "" {
+		if keyword := values.Get(names.Keyword); keyword != "" {
+			opts.Search = &SearchQuery{
+				Fields:  strings.Split(fields, sep),
+				Keyword: keyword,
+			}
+		}
+	}
+	if sel := values.Get(names.Select); sel != "" {
+		opts.Select = strings.Split(sel, sep)
+	}
+	if group := values.Get(names.Group); group != "" {
+		opts.GroupBy = strings.Split(group, sep)
+		// force selection equal to group. this prevent full group by only
+		opts.Select = strings.Split(group, sep)
+
+		for _, sort := range opts.Sort {
+			opts.GroupBy = append(opts.GroupBy, sort.Field)
+		}
+	}
+
+	_, hasAfter := values[names.Cursor]
+	_, hasBefore := values[names.Before]
+	if hasAfter || hasBefore {
+		opts.Cursor = &CursorQuery{
+			After:  values.Get(names.Cursor),
+			Before: values.Get(names.Before),
+		}
+	}
+
+	if filter := values.Get(names.Filter); filter != "" {
+		if expr, err := p.parseFilter(filter); err == nil {
+			opts.Filter = expr
+		}
+	}
+
+	// walk the keys in order so that search_and fields and comparisons come
+	// out the same for equal queries
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		val := values[key]
+		if names.reserved(key) {
+			continue
+		}
+		// Handle both searchAnd.field=keyword and search_and.field=keyword formats
+		if fieldName, ok := names.searchAndField(key); ok && len(val) > 0 {
+			if fieldName != "" && val[0] != "" {
+				if opts.SearchAnd == nil {
+					opts.SearchAnd = &SearchQueryAnd{Fields: []*SearchField{}}
+				}
+				opts.SearchAnd.Fields = append(opts.SearchAnd.Fields, &SearchField{
+					Field:   fieldName,
+					Keyword: val[0],
+				})
+			}
+			continue
+		}
+		if matches := comparisonPattern.FindStringSubmatch(key); len(matches) == 3 && p.allowsOperator(ComparisonOp(matches[2])) {
+			op := ComparisonOp(matches[2])
+			opts.Comparisons = append(opts.Comparisons, ComparisonFilter{
+				Field: matches[1],
+				Op:    op,
+				Value: p.normalizeValue(op, val[0]),
+			})
+			continue
+		}
+		opts.Filters[key] = val[0]
+	}
+	return opts
+}
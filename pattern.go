@@ -0,0 +1,154 @@
+package slicer
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+var (
+	ErrRegexDisabled error = errors.New("slicer: regex comparisons are disabled")
+	ErrInvalidRegex  error = errors.New("slicer: invalid regular expression")
+)
+
+// patternCacheSize bounds the number of compiled patterns kept by
+// patternCache.
+const patternCacheSize = 256
+
+// patternCache holds the most recently used compiled regular expressions
+// for LIKE and REGEX comparisons, so that SlicePage compiles a pattern once
+// per page rather than once per row while client supplied patterns cannot
+// grow it without bound.
+var patternCache = NewLRUCache(patternCacheSize)
+
+// checkComparison rejects comparisons the paginator is not configured to
+// evaluate.
+func checkComparison(cmp ComparisonFilter, config Config) error {
+	if cmp.Op != REGEX {
+		return nil
+	}
+	if !config.AllowRegex {
+		return ErrRegexDisabled
+	}
+	if _, err := regexp.Compile(cmp.Value); err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidRegex, err.Error())
+	}
+	return nil
+}
+
+// checkComparisons runs checkComparison on every comparison of opts that
+// targets an allowed field, including those nested in opts.Filter, and
+// checkFilterFields on opts.Filter.
+func checkComparisons(opts QueryOptions, allowed map[string]string, config Config) error {
+	if err := checkFilterFields(opts.Filter, allowed, false); err != nil {
+		return err
+	}
+
+	var err error
+	visit := func(cmp ComparisonFilter) {
+		if _, ok := allowed[cmp.Field]; ok && err == nil {
+			err = checkComparison(cmp, config)
+		}
+	}
+	for _, cmp := range opts.Comparisons {
+		visit(cmp)
+	}
+	opts.Filter.each(visit)
+	return err
+}
+
+// escapeLike escapes the LIKE wildcards in a literal keyword.
+func escapeLike(keyword string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(keyword)
+}
+
+// likePattern converts the value of a pattern comparison into an SQL LIKE
+// pattern.
+func likePattern(value string, op ComparisonOp) string {
+	switch op {
+	case PREFIX:
+		return escapeLike(value) + "%"
+	case SUFFIX:
+		return "%" + escapeLike(value)
+	default:
+		return value
+	}
+}
+
+// likeRegexp translates an SQL LIKE pattern into an anchored regular
+// expression. A backslash escapes the following character.
+func likeRegexp(pattern string, fold bool) string {
+	var b strings.Builder
+	if fold {
+		b.WriteString("(?i)")
+	}
+	b.WriteString("(?s)^")
+
+	escaped := false
+	for _, r := range pattern {
+		switch {
+		case escaped:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == '%':
+			b.WriteString(".*")
+		case r == '_':
+			b.WriteString(".")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	b.WriteString("$")
+	return b.String()
+}
+
+// compilePattern returns the cached compiled form of expr.
+func compilePattern(expr string) (*regexp.Regexp, error) {
+	if re, ok := patternCache.Get("", expr); ok {
+		return re.(*regexp.Regexp), nil
+	}
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, err
+	}
+	patternCache.Set("", expr, re, 0)
+	return re, nil
+}
+
+// matchPattern evaluates a like, ilike, prefix, suffix or regex comparison
+// against a field value. NULL values never match.
+func matchPattern(fieldVal any, pattern string, op ComparisonOp) bool {
+	if isNull(fieldVal) {
+		return false
+	}
+	if rv := reflect.ValueOf(fieldVal); rv.Kind() == reflect.Ptr {
+		fieldVal = rv.Elem().Interface()
+	}
+	subject := fmt.Sprintf("%v", fieldVal)
+
+	var expr string
+	switch op {
+	case PREFIX:
+		return strings.HasPrefix(strings.ToLower(subject), strings.ToLower(pattern))
+	case SUFFIX:
+		return strings.HasSuffix(strings.ToLower(subject), strings.ToLower(pattern))
+	case LIKE:
+		expr = likeRegexp(pattern, false)
+	case ILIKE:
+		expr = likeRegexp(pattern, true)
+	case REGEX:
+		expr = pattern
+	default:
+		return false
+	}
+
+	re, err := compilePattern(expr)
+	if err != nil {
+		return false
+	}
+	return re.MatchString(subject)
+}
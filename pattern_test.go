@@ -0,0 +1,17 @@
+package slicer
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestPatternCacheIsBounded(t *testing.T) {
+	for i := range patternCacheSize * 2 {
+		if !matchPattern("row"+strconv.Itoa(i), "row"+strconv.Itoa(i), LIKE) {
+			t.Fatalf("Expected pattern %d to match", i)
+		}
+	}
+	if n := patternCache.Len(); n > patternCacheSize {
+		t.Errorf("Expected at most %d cached patterns, got %d", patternCacheSize, n)
+	}
+}
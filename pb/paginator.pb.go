@@ -33,6 +33,10 @@ type QueryOptions struct {
 	Comparisons   []*ComparisonFilter    `protobuf:"bytes,7,rep,name=comparisons,proto3" json:"comparisons,omitempty"`
 	GroupBy       []string               `protobuf:"bytes,8,rep,name=group_by,json=groupBy,proto3" json:"group_by,omitempty"`
 	SearchAnd     *SearchQueryAnd        `protobuf:"bytes,9,opt,name=search_and,json=searchAnd,proto3" json:"search_and,omitempty"`
+	Cursor        *CursorQuery           `protobuf:"bytes,10,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	Filter        *FilterExpr            `protobuf:"bytes,11,opt,name=filter,proto3" json:"filter,omitempty"`
+	Separator     string                 `protobuf:"bytes,12,opt,name=separator,proto3" json:"separator,omitempty"`
+	Count         string                 `protobuf:"bytes,13,opt,name=count,proto3" json:"count,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -130,6 +134,34 @@ func (x *QueryOptions) GetSearchAnd() *SearchQueryAnd {
 	return nil
 }
 
+func (x *QueryOptions) GetCursor() *CursorQuery {
+	if x != nil {
+		return x.Cursor
+	}
+	return nil
+}
+
+func (x *QueryOptions) GetFilter() *FilterExpr {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *QueryOptions) GetSeparator() string {
+	if x != nil {
+		return x.Separator
+	}
+	return ""
+}
+
+func (x *QueryOptions) GetCount() string {
+	if x != nil {
+		return x.Count
+	}
+	return ""
+}
+
 type SortField struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Field         string                 `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
@@ -330,6 +362,58 @@ func (x *SearchQueryAnd) GetFields() []*SearchField {
 	return nil
 }
 
+type CursorQuery struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	After         string                 `protobuf:"bytes,1,opt,name=after,proto3" json:"after,omitempty"`
+	Before        string                 `protobuf:"bytes,2,opt,name=before,proto3" json:"before,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CursorQuery) Reset() {
+	*x = CursorQuery{}
+	mi := &file_pb_paginator_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CursorQuery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CursorQuery) ProtoMessage() {}
+
+func (x *CursorQuery) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_paginator_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CursorQuery.ProtoReflect.Descriptor instead.
+func (*CursorQuery) Descriptor() ([]byte, []int) {
+	return file_pb_paginator_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CursorQuery) GetAfter() string {
+	if x != nil {
+		return x.After
+	}
+	return ""
+}
+
+func (x *CursorQuery) GetBefore() string {
+	if x != nil {
+		return x.Before
+	}
+	return ""
+}
+
 type ComparisonFilter struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Field         string                 `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
@@ -341,7 +425,7 @@ type ComparisonFilter struct {
 
 func (x *ComparisonFilter) Reset() {
 	*x = ComparisonFilter{}
-	mi := &file_pb_paginator_proto_msgTypes[5]
+	mi := &file_pb_paginator_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -353,7 +437,7 @@ func (x *ComparisonFilter) String() string {
 func (*ComparisonFilter) ProtoMessage() {}
 
 func (x *ComparisonFilter) ProtoReflect() protoreflect.Message {
-	mi := &file_pb_paginator_proto_msgTypes[5]
+	mi := &file_pb_paginator_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -366,7 +450,7 @@ func (x *ComparisonFilter) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ComparisonFilter.ProtoReflect.Descriptor instead.
 func (*ComparisonFilter) Descriptor() ([]byte, []int) {
-	return file_pb_paginator_proto_rawDescGZIP(), []int{5}
+	return file_pb_paginator_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *ComparisonFilter) GetField() string {
@@ -390,19 +474,90 @@ func (x *ComparisonFilter) GetValue() string {
 	return ""
 }
 
+type FilterExpr struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Op            string                 `protobuf:"bytes,1,opt,name=op,proto3" json:"op,omitempty"`
+	Children      []*FilterExpr          `protobuf:"bytes,2,rep,name=children,proto3" json:"children,omitempty"`
+	Comparison    *ComparisonFilter      `protobuf:"bytes,3,opt,name=comparison,proto3" json:"comparison,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FilterExpr) Reset() {
+	*x = FilterExpr{}
+	mi := &file_pb_paginator_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FilterExpr) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FilterExpr) ProtoMessage() {}
+
+func (x *FilterExpr) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_paginator_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FilterExpr.ProtoReflect.Descriptor instead.
+func (*FilterExpr) Descriptor() ([]byte, []int) {
+	return file_pb_paginator_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *FilterExpr) GetOp() string {
+	if x != nil {
+		return x.Op
+	}
+	return ""
+}
+
+func (x *FilterExpr) GetChildren() []*FilterExpr {
+	if x != nil {
+		return x.Children
+	}
+	return nil
+}
+
+func (x *FilterExpr) GetComparison() *ComparisonFilter {
+	if x != nil {
+		return x.Comparison
+	}
+	return nil
+}
+
 type PageData struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Total         int64                  `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
 	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
 	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
 	Items         []byte                 `protobuf:"bytes,4,opt,name=items,proto3" json:"items,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,5,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	PrevCursor    string                 `protobuf:"bytes,6,opt,name=prev_cursor,json=prevCursor,proto3" json:"prev_cursor,omitempty"`
+	CountStrategy string                 `protobuf:"bytes,7,opt,name=count_strategy,json=countStrategy,proto3" json:"count_strategy,omitempty"`
+	TotalCapped   bool                   `protobuf:"varint,8,opt,name=total_capped,json=totalCapped,proto3" json:"total_capped,omitempty"`
+	HasMore       bool                   `protobuf:"varint,9,opt,name=has_more,json=hasMore,proto3" json:"has_more,omitempty"`
+	TotalPages    int64                  `protobuf:"varint,10,opt,name=total_pages,json=totalPages,proto3" json:"total_pages,omitempty"`
+	HasNext       bool                   `protobuf:"varint,11,opt,name=has_next,json=hasNext,proto3" json:"has_next,omitempty"`
+	HasPrev       bool                   `protobuf:"varint,12,opt,name=has_prev,json=hasPrev,proto3" json:"has_prev,omitempty"`
+	From          int64                  `protobuf:"varint,13,opt,name=from,proto3" json:"from,omitempty"`
+	To            int64                  `protobuf:"varint,14,opt,name=to,proto3" json:"to,omitempty"`
+	Links         *PageLinks             `protobuf:"bytes,15,opt,name=links,proto3" json:"links,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *PageData) Reset() {
 	*x = PageData{}
-	mi := &file_pb_paginator_proto_msgTypes[6]
+	mi := &file_pb_paginator_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -414,7 +569,7 @@ func (x *PageData) String() string {
 func (*PageData) ProtoMessage() {}
 
 func (x *PageData) ProtoReflect() protoreflect.Message {
-	mi := &file_pb_paginator_proto_msgTypes[6]
+	mi := &file_pb_paginator_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -427,7 +582,7 @@ func (x *PageData) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PageData.ProtoReflect.Descriptor instead.
 func (*PageData) Descriptor() ([]byte, []int) {
-	return file_pb_paginator_proto_rawDescGZIP(), []int{6}
+	return file_pb_paginator_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *PageData) GetTotal() int64 {
@@ -458,19 +613,107 @@ func (x *PageData) GetItems() []byte {
 	return nil
 }
 
+func (x *PageData) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+func (x *PageData) GetPrevCursor() string {
+	if x != nil {
+		return x.PrevCursor
+	}
+	return ""
+}
+
+func (x *PageData) GetCountStrategy() string {
+	if x != nil {
+		return x.CountStrategy
+	}
+	return ""
+}
+
+func (x *PageData) GetTotalCapped() bool {
+	if x != nil {
+		return x.TotalCapped
+	}
+	return false
+}
+
+func (x *PageData) GetHasMore() bool {
+	if x != nil {
+		return x.HasMore
+	}
+	return false
+}
+
+func (x *PageData) GetTotalPages() int64 {
+	if x != nil {
+		return x.TotalPages
+	}
+	return 0
+}
+
+func (x *PageData) GetHasNext() bool {
+	if x != nil {
+		return x.HasNext
+	}
+	return false
+}
+
+func (x *PageData) GetHasPrev() bool {
+	if x != nil {
+		return x.HasPrev
+	}
+	return false
+}
+
+func (x *PageData) GetFrom() int64 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *PageData) GetTo() int64 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+func (x *PageData) GetLinks() *PageLinks {
+	if x != nil {
+		return x.Links
+	}
+	return nil
+}
+
 type PageDataBuf struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Total         int64                  `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
 	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
 	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
 	Items         *anypb.Any             `protobuf:"bytes,4,opt,name=items,proto3" json:"items,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,5,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	PrevCursor    string                 `protobuf:"bytes,6,opt,name=prev_cursor,json=prevCursor,proto3" json:"prev_cursor,omitempty"`
+	CountStrategy string                 `protobuf:"bytes,7,opt,name=count_strategy,json=countStrategy,proto3" json:"count_strategy,omitempty"`
+	TotalCapped   bool                   `protobuf:"varint,8,opt,name=total_capped,json=totalCapped,proto3" json:"total_capped,omitempty"`
+	HasMore       bool                   `protobuf:"varint,9,opt,name=has_more,json=hasMore,proto3" json:"has_more,omitempty"`
+	TotalPages    int64                  `protobuf:"varint,10,opt,name=total_pages,json=totalPages,proto3" json:"total_pages,omitempty"`
+	HasNext       bool                   `protobuf:"varint,11,opt,name=has_next,json=hasNext,proto3" json:"has_next,omitempty"`
+	HasPrev       bool                   `protobuf:"varint,12,opt,name=has_prev,json=hasPrev,proto3" json:"has_prev,omitempty"`
+	From          int64                  `protobuf:"varint,13,opt,name=from,proto3" json:"from,omitempty"`
+	To            int64                  `protobuf:"varint,14,opt,name=to,proto3" json:"to,omitempty"`
+	Links         *PageLinks             `protobuf:"bytes,15,opt,name=links,proto3" json:"links,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *PageDataBuf) Reset() {
 	*x = PageDataBuf{}
-	mi := &file_pb_paginator_proto_msgTypes[7]
+	mi := &file_pb_paginator_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -482,7 +725,7 @@ func (x *PageDataBuf) String() string {
 func (*PageDataBuf) ProtoMessage() {}
 
 func (x *PageDataBuf) ProtoReflect() protoreflect.Message {
-	mi := &file_pb_paginator_proto_msgTypes[7]
+	mi := &file_pb_paginator_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -495,7 +738,7 @@ func (x *PageDataBuf) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PageDataBuf.ProtoReflect.Descriptor instead.
 func (*PageDataBuf) Descriptor() ([]byte, []int) {
-	return file_pb_paginator_proto_rawDescGZIP(), []int{7}
+	return file_pb_paginator_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *PageDataBuf) GetTotal() int64 {
@@ -526,11 +769,164 @@ func (x *PageDataBuf) GetItems() *anypb.Any {
 	return nil
 }
 
+func (x *PageDataBuf) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+func (x *PageDataBuf) GetPrevCursor() string {
+	if x != nil {
+		return x.PrevCursor
+	}
+	return ""
+}
+
+func (x *PageDataBuf) GetCountStrategy() string {
+	if x != nil {
+		return x.CountStrategy
+	}
+	return ""
+}
+
+func (x *PageDataBuf) GetTotalCapped() bool {
+	if x != nil {
+		return x.TotalCapped
+	}
+	return false
+}
+
+func (x *PageDataBuf) GetHasMore() bool {
+	if x != nil {
+		return x.HasMore
+	}
+	return false
+}
+
+func (x *PageDataBuf) GetTotalPages() int64 {
+	if x != nil {
+		return x.TotalPages
+	}
+	return 0
+}
+
+func (x *PageDataBuf) GetHasNext() bool {
+	if x != nil {
+		return x.HasNext
+	}
+	return false
+}
+
+func (x *PageDataBuf) GetHasPrev() bool {
+	if x != nil {
+		return x.HasPrev
+	}
+	return false
+}
+
+func (x *PageDataBuf) GetFrom() int64 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *PageDataBuf) GetTo() int64 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+func (x *PageDataBuf) GetLinks() *PageLinks {
+	if x != nil {
+		return x.Links
+	}
+	return nil
+}
+
+type PageLinks struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Self          string                 `protobuf:"bytes,1,opt,name=self,proto3" json:"self,omitempty"`
+	Next          string                 `protobuf:"bytes,2,opt,name=next,proto3" json:"next,omitempty"`
+	Prev          string                 `protobuf:"bytes,3,opt,name=prev,proto3" json:"prev,omitempty"`
+	First         string                 `protobuf:"bytes,4,opt,name=first,proto3" json:"first,omitempty"`
+	Last          string                 `protobuf:"bytes,5,opt,name=last,proto3" json:"last,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PageLinks) Reset() {
+	*x = PageLinks{}
+	mi := &file_pb_paginator_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PageLinks) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PageLinks) ProtoMessage() {}
+
+func (x *PageLinks) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_paginator_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PageLinks.ProtoReflect.Descriptor instead.
+func (*PageLinks) Descriptor() ([]byte, []int) {
+	return file_pb_paginator_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *PageLinks) GetSelf() string {
+	if x != nil {
+		return x.Self
+	}
+	return ""
+}
+
+func (x *PageLinks) GetNext() string {
+	if x != nil {
+		return x.Next
+	}
+	return ""
+}
+
+func (x *PageLinks) GetPrev() string {
+	if x != nil {
+		return x.Prev
+	}
+	return ""
+}
+
+func (x *PageLinks) GetFirst() string {
+	if x != nil {
+		return x.First
+	}
+	return ""
+}
+
+func (x *PageLinks) GetLast() string {
+	if x != nil {
+		return x.Last
+	}
+	return ""
+}
+
 var File_pb_paginator_proto protoreflect.FileDescriptor
 
 const file_pb_paginator_proto_rawDesc = "" +
 	"\n" +
-	"\x12pb/paginator.proto\x12\tslicer.v1\x1a\x19google/protobuf/any.proto\"\xba\x03\n" +
+	"\x12pb/paginator.proto\x12\tslicer.v1\x1a\x19google/protobuf/any.proto\"\xcd\x04\n" +
 	"\fQueryOptions\x12\x12\n" +
 	"\x04page\x18\x01 \x01(\rR\x04page\x12\x14\n" +
 	"\x05limit\x18\x02 \x01(\rR\x05limit\x12(\n" +
@@ -541,7 +937,12 @@ const file_pb_paginator_proto_rawDesc = "" +
 	"\vcomparisons\x18\a \x03(\v2\x1b.slicer.v1.ComparisonFilterR\vcomparisons\x12\x19\n" +
 	"\bgroup_by\x18\b \x03(\tR\agroupBy\x128\n" +
 	"\n" +
-	"search_and\x18\t \x01(\v2\x19.slicer.v1.SearchQueryAndR\tsearchAnd\x1a:\n" +
+	"search_and\x18\t \x01(\v2\x19.slicer.v1.SearchQueryAndR\tsearchAnd\x12.\n" +
+	"\x06cursor\x18\n" +
+	" \x01(\v2\x16.slicer.v1.CursorQueryR\x06cursor\x12-\n" +
+	"\x06filter\x18\v \x01(\v2\x15.slicer.v1.FilterExprR\x06filter\x12\x1c\n" +
+	"\tseparator\x18\f \x01(\tR\tseparator\x12\x14\n" +
+	"\x05count\x18\r \x01(\tR\x05count\x1a:\n" +
 	"\fFiltersEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"5\n" +
@@ -555,21 +956,67 @@ const file_pb_paginator_proto_rawDesc = "" +
 	"\x05field\x18\x01 \x01(\tR\x05field\x12\x18\n" +
 	"\akeyword\x18\x02 \x01(\tR\akeyword\"@\n" +
 	"\x0eSearchQueryAnd\x12.\n" +
-	"\x06fields\x18\x01 \x03(\v2\x16.slicer.v1.SearchFieldR\x06fields\"N\n" +
+	"\x06fields\x18\x01 \x03(\v2\x16.slicer.v1.SearchFieldR\x06fields\";\n" +
+	"\vCursorQuery\x12\x14\n" +
+	"\x05after\x18\x01 \x01(\tR\x05after\x12\x16\n" +
+	"\x06before\x18\x02 \x01(\tR\x06before\"N\n" +
 	"\x10ComparisonFilter\x12\x14\n" +
 	"\x05field\x18\x01 \x01(\tR\x05field\x12\x0e\n" +
 	"\x02op\x18\x02 \x01(\tR\x02op\x12\x14\n" +
-	"\x05value\x18\x03 \x01(\tR\x05value\"`\n" +
+	"\x05value\x18\x03 \x01(\tR\x05value\"\x8c\x01\n" +
+	"\n" +
+	"FilterExpr\x12\x0e\n" +
+	"\x02op\x18\x01 \x01(\tR\x02op\x121\n" +
+	"\bchildren\x18\x02 \x03(\v2\x15.slicer.v1.FilterExprR\bchildren\x12;\n" +
+	"\n" +
+	"comparison\x18\x03 \x01(\v2\x1b.slicer.v1.ComparisonFilterR\n" +
+	"comparison\"\xae\x03\n" +
 	"\bPageData\x12\x14\n" +
 	"\x05total\x18\x01 \x01(\x03R\x05total\x12\x12\n" +
 	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x14\n" +
 	"\x05limit\x18\x03 \x01(\x05R\x05limit\x12\x14\n" +
-	"\x05items\x18\x04 \x01(\fR\x05items\"y\n" +
+	"\x05items\x18\x04 \x01(\fR\x05items\x12\x1f\n" +
+	"\vnext_cursor\x18\x05 \x01(\tR\n" +
+	"nextCursor\x12\x1f\n" +
+	"\vprev_cursor\x18\x06 \x01(\tR\n" +
+	"prevCursor\x12%\n" +
+	"\x0ecount_strategy\x18\a \x01(\tR\rcountStrategy\x12!\n" +
+	"\ftotal_capped\x18\b \x01(\bR\vtotalCapped\x12\x19\n" +
+	"\bhas_more\x18\t \x01(\bR\ahasMore\x12\x1f\n" +
+	"\vtotal_pages\x18\n" +
+	" \x01(\x03R\n" +
+	"totalPages\x12\x19\n" +
+	"\bhas_next\x18\v \x01(\bR\ahasNext\x12\x19\n" +
+	"\bhas_prev\x18\f \x01(\bR\ahasPrev\x12\x12\n" +
+	"\x04from\x18\r \x01(\x03R\x04from\x12\x0e\n" +
+	"\x02to\x18\x0e \x01(\x03R\x02to\x12*\n" +
+	"\x05links\x18\x0f \x01(\v2\x14.slicer.v1.PageLinksR\x05links\"\xc7\x03\n" +
 	"\vPageDataBuf\x12\x14\n" +
 	"\x05total\x18\x01 \x01(\x03R\x05total\x12\x12\n" +
 	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x14\n" +
 	"\x05limit\x18\x03 \x01(\x05R\x05limit\x12*\n" +
-	"\x05items\x18\x04 \x01(\v2\x14.google.protobuf.AnyR\x05itemsB'Z%github.com/godev90/slicer/pb;slicerpbb\x06proto3"
+	"\x05items\x18\x04 \x01(\v2\x14.google.protobuf.AnyR\x05items\x12\x1f\n" +
+	"\vnext_cursor\x18\x05 \x01(\tR\n" +
+	"nextCursor\x12\x1f\n" +
+	"\vprev_cursor\x18\x06 \x01(\tR\n" +
+	"prevCursor\x12%\n" +
+	"\x0ecount_strategy\x18\a \x01(\tR\rcountStrategy\x12!\n" +
+	"\ftotal_capped\x18\b \x01(\bR\vtotalCapped\x12\x19\n" +
+	"\bhas_more\x18\t \x01(\bR\ahasMore\x12\x1f\n" +
+	"\vtotal_pages\x18\n" +
+	" \x01(\x03R\n" +
+	"totalPages\x12\x19\n" +
+	"\bhas_next\x18\v \x01(\bR\ahasNext\x12\x19\n" +
+	"\bhas_prev\x18\f \x01(\bR\ahasPrev\x12\x12\n" +
+	"\x04from\x18\r \x01(\x03R\x04from\x12\x0e\n" +
+	"\x02to\x18\x0e \x01(\x03R\x02to\x12*\n" +
+	"\x05links\x18\x0f \x01(\v2\x14.slicer.v1.PageLinksR\x05links\"q\n" +
+	"\tPageLinks\x12\x12\n" +
+	"\x04self\x18\x01 \x01(\tR\x04self\x12\x12\n" +
+	"\x04next\x18\x02 \x01(\tR\x04next\x12\x12\n" +
+	"\x04prev\x18\x03 \x01(\tR\x04prev\x12\x14\n" +
+	"\x05first\x18\x04 \x01(\tR\x05first\x12\x12\n" +
+	"\x04last\x18\x05 \x01(\tR\x04lastB'Z%github.com/godev90/slicer/pb;slicerpbb\x06proto3"
 
 var (
 	file_pb_paginator_proto_rawDescOnce sync.Once
@@ -583,32 +1030,41 @@ func file_pb_paginator_proto_rawDescGZIP() []byte {
 	return file_pb_paginator_proto_rawDescData
 }
 
-var file_pb_paginator_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_pb_paginator_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
 var file_pb_paginator_proto_goTypes = []any{
 	(*QueryOptions)(nil),     // 0: slicer.v1.QueryOptions
 	(*SortField)(nil),        // 1: slicer.v1.SortField
 	(*SearchQuery)(nil),      // 2: slicer.v1.SearchQuery
 	(*SearchField)(nil),      // 3: slicer.v1.SearchField
 	(*SearchQueryAnd)(nil),   // 4: slicer.v1.SearchQueryAnd
-	(*ComparisonFilter)(nil), // 5: slicer.v1.ComparisonFilter
-	(*PageData)(nil),         // 6: slicer.v1.PageData
-	(*PageDataBuf)(nil),      // 7: slicer.v1.PageDataBuf
-	nil,                      // 8: slicer.v1.QueryOptions.FiltersEntry
-	(*anypb.Any)(nil),        // 9: google.protobuf.Any
+	(*CursorQuery)(nil),      // 5: slicer.v1.CursorQuery
+	(*ComparisonFilter)(nil), // 6: slicer.v1.ComparisonFilter
+	(*FilterExpr)(nil),       // 7: slicer.v1.FilterExpr
+	(*PageData)(nil),         // 8: slicer.v1.PageData
+	(*PageDataBuf)(nil),      // 9: slicer.v1.PageDataBuf
+	(*PageLinks)(nil),        // 10: slicer.v1.PageLinks
+	nil,                      // 11: slicer.v1.QueryOptions.FiltersEntry
+	(*anypb.Any)(nil),        // 12: google.protobuf.Any
 }
 var file_pb_paginator_proto_depIdxs = []int32{
-	1, // 0: slicer.v1.QueryOptions.sort:type_name -> slicer.v1.SortField
-	2, // 1: slicer.v1.QueryOptions.search:type_name -> slicer.v1.SearchQuery
-	8, // 2: slicer.v1.QueryOptions.filters:type_name -> slicer.v1.QueryOptions.FiltersEntry
-	5, // 3: slicer.v1.QueryOptions.comparisons:type_name -> slicer.v1.ComparisonFilter
-	4, // 4: slicer.v1.QueryOptions.search_and:type_name -> slicer.v1.SearchQueryAnd
-	3, // 5: slicer.v1.SearchQueryAnd.fields:type_name -> slicer.v1.SearchField
-	9, // 6: slicer.v1.PageDataBuf.items:type_name -> google.protobuf.Any
-	7, // [7:7] is the sub-list for method output_type
-	7, // [7:7] is the sub-list for method input_type
-	7, // [7:7] is the sub-list for extension type_name
-	7, // [7:7] is the sub-list for extension extendee
-	0, // [0:7] is the sub-list for field type_name
+	1,  // 0: slicer.v1.QueryOptions.sort:type_name -> slicer.v1.SortField
+	2,  // 1: slicer.v1.QueryOptions.search:type_name -> slicer.v1.SearchQuery
+	11, // 2: slicer.v1.QueryOptions.filters:type_name -> slicer.v1.QueryOptions.FiltersEntry
+	6,  // 3: slicer.v1.QueryOptions.comparisons:type_name -> slicer.v1.ComparisonFilter
+	4,  // 4: slicer.v1.QueryOptions.search_and:type_name -> slicer.v1.SearchQueryAnd
+	5,  // 5: slicer.v1.QueryOptions.cursor:type_name -> slicer.v1.CursorQuery
+	7,  // 6: slicer.v1.QueryOptions.filter:type_name -> slicer.v1.FilterExpr
+	3,  // 7: slicer.v1.SearchQueryAnd.fields:type_name -> slicer.v1.SearchField
+	7,  // 8: slicer.v1.FilterExpr.children:type_name -> slicer.v1.FilterExpr
+	6,  // 9: slicer.v1.FilterExpr.comparison:type_name -> slicer.v1.ComparisonFilter
+	10, // 10: slicer.v1.PageData.links:type_name -> slicer.v1.PageLinks
+	12, // 11: slicer.v1.PageDataBuf.items:type_name -> google.protobuf.Any
+	10, // 12: slicer.v1.PageDataBuf.links:type_name -> slicer.v1.PageLinks
+	13, // [13:13] is the sub-list for method output_type
+	13, // [13:13] is the sub-list for method input_type
+	13, // [13:13] is the sub-list for extension type_name
+	13, // [13:13] is the sub-list for extension extendee
+	0,  // [0:13] is the sub-list for field type_name
 }
 
 func init() { file_pb_paginator_proto_init() }
@@ -622,7 +1078,7 @@ func file_pb_paginator_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pb_paginator_proto_rawDesc), len(file_pb_paginator_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   9,
+			NumMessages:   12,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -0,0 +1,219 @@
+package slicer
+
+import (
+	"iter"
+	"slices"
+)
+
+// Pipeline is a lazy sequence of operations over the elements of an
+// iter.Seq. Stages run element by element when a terminal operation
+// (Collect, ReducePipeline, Page) consumes the pipeline, and stop pulling
+// from the source as soon as the result is known, so taking the first page
+// of a filtered source reads only as far as that page. Pipelines are values:
+// every stage returns a new one and leaves its receiver untouched.
+type Pipeline[T any] struct {
+	seq iter.Seq[T]
+}
+
+// NewPipeline returns a pipeline over the elements of s.
+func NewPipeline[T any](s []T) Pipeline[T] {
+	return Pipeline[T]{seq: slices.Values(s)}
+}
+
+// NewPipelineSeq returns a pipeline over seq. The pipeline can be consumed
+// more than once if seq can.
+func NewPipelineSeq[T any](seq iter.Seq[T]) Pipeline[T] {
+	return Pipeline[T]{seq: seq}
+}
+
+// Seq returns the pipeline as an iter.Seq.
+func (p Pipeline[T]) Seq() iter.Seq[T] {
+	return p.seq
+}
+
+// Filter keeps the elements for which keep returns true.
+func (p Pipeline[T]) Filter(keep func(T) bool) Pipeline[T] {
+	return Pipeline[T]{seq: func(yield func(T) bool) {
+		for v := range p.seq {
+			if keep(v) && !yield(v) {
+				return
+			}
+		}
+	}}
+}
+
+// Take keeps the first n elements.
+func (p Pipeline[T]) Take(n int) Pipeline[T] {
+	return Pipeline[T]{seq: func(yield func(T) bool) {
+		if n <= 0 {
+			return
+		}
+		taken := 0
+		for v := range p.seq {
+			if !yield(v) {
+				return
+			}
+			if taken++; taken == n {
+				return
+			}
+		}
+	}}
+}
+
+// Skip drops the first n elements.
+func (p Pipeline[T]) Skip(n int) Pipeline[T] {
+	return Pipeline[T]{seq: func(yield func(T) bool) {
+		skipped := 0
+		for v := range p.seq {
+			if skipped < n {
+				skipped++
+				continue
+			}
+			if !yield(v) {
+				return
+			}
+		}
+	}}
+}
+
+// Distinct drops elements whose key was already seen, keeping the first
+// one. A nil key compares the elements themselves. Keys must be comparable:
+// Distinct panics otherwise, like a map would.
+func (p Pipeline[T]) Distinct(key func(T) any) Pipeline[T] {
+	return Pipeline[T]{seq: func(yield func(T) bool) {
+		seen := make(map[any]struct{})
+		for v := range p.seq {
+			var k any = v
+			if key != nil {
+				k = key(v)
+			}
+			if _, ok := seen[k]; ok {
+				continue
+			}
+			seen[k] = struct{}{}
+			if !yield(v) {
+				return
+			}
+		}
+	}}
+}
+
+// SortBy orders the elements with compare, which returns a negative number
+// when a sorts before b, a positive one when after and zero when they are
+// equal, such as cmp.Compare applied to a key. The sort is stable. Sorting
+// needs every element, so this stage reads the whole source before yielding
+// the first one.
+func (p Pipeline[T]) SortBy(compare func(a, b T) int) Pipeline[T] {
+	return Pipeline[T]{seq: func(yield func(T) bool) {
+		sorted := slices.Collect(p.seq)
+		slices.SortStableFunc(sorted, compare)
+		for _, v := range sorted {
+			if !yield(v) {
+				return
+			}
+		}
+	}}
+}
+
+// Collect runs the pipeline and returns its elements.
+func (p Pipeline[T]) Collect() []T {
+	out := slices.Collect(p.seq)
+	if out == nil {
+		out = []T{}
+	}
+	return out
+}
+
+// MapPipeline adds a stage converting every element with fn. It is a
+// function rather than a method because methods cannot introduce the type
+// of the result.
+func MapPipeline[T, U any](p Pipeline[T], fn func(T) U) Pipeline[U] {
+	return Pipeline[U]{seq: func(yield func(U) bool) {
+		for v := range p.seq {
+			if !yield(fn(v)) {
+				return
+			}
+		}
+	}}
+}
+
+// ReducePipeline runs the pipeline, folding its elements into a single
+// value like Reduce.
+func ReducePipeline[T, A any](p Pipeline[T], initial A, fn func(A, T) A) A {
+	acc := initial
+	for v := range p.seq {
+		acc = fn(acc, v)
+	}
+	return acc
+}
+
+// Paginator runs the pipeline and returns a SlicePaginator over its
+// elements, for query options that need the allowed fields: comparisons,
+// filters, search, sorting by field and cursors.
+func (p Pipeline[T]) Paginator(allowedFields map[string]string) *SlicePaginator[T] {
+	return NewSlicePaginator(p.Collect(), allowedFields)
+}
+
+// Page runs the pipeline and returns the page selected by the page, limit
+// and offset of opts, shaped like a SlicePage result. Only the elements up
+// to the end of the page are kept. How much of the rest is read depends on
+// opts.Count: CountNone (the default) reads a single element past the page
+// to set HasMore, a capped total counts up to the default cap and only
+// CountExact reads the whole source. Conditions, search, sorting and cursors
+// in opts are not applied; express them as stages or use Paginator.
+func (p Pipeline[T]) Page(opts QueryOptions) (PageData, error) {
+	offset := opts.Offset
+	if offset == 0 && opts.Page > 1 && opts.Limit > 0 {
+		offset = (opts.Page - 1) * opts.Limit
+	}
+	end := -1
+	if opts.Limit > 0 {
+		end = offset + opts.Limit
+	}
+
+	// counting would defeat the laziness, so it is opt-in
+	strategy := Config{CountStrategy: CountNone}.countStrategy(opts)
+	if strategy == CountEstimated {
+		// nothing to estimate from in memory
+		strategy = CountCapped
+	}
+	countCap := Config{}.countCap()
+
+	// stop is the number of elements to read, or -1 for all of them
+	stop := -1
+	switch {
+	case end < 0:
+	case strategy == CountNone:
+		stop = end + 1
+	case strategy == CountCapped:
+		stop = max(end, countCap) + 1
+	}
+
+	items := []T{}
+	read := 0
+	for v := range p.seq {
+		if read >= offset && (end < 0 || read < end) {
+			items = append(items, v)
+		}
+		if read++; read == stop {
+			break
+		}
+	}
+
+	data := PageData{
+		Items:         items,
+		Total:         int64(read),
+		Page:          opts.Page,
+		Limit:         opts.Limit,
+		CountStrategy: strategy,
+		HasMore:       end >= 0 && read > end,
+	}
+	switch {
+	case strategy == CountNone:
+		data.Total = -1
+	case strategy == CountCapped && read > countCap:
+		data.Total, data.TotalCapped = int64(countCap), true
+	}
+	opts.Offset = offset
+	return withMeta(data, opts), nil
+}
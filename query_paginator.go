@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"reflect"
+	"slices"
 	"sort"
 	"strings"
 	"time"
@@ -24,17 +25,82 @@ type (
 )
 
 func QueryPage[T orm.Tabler](paginator Paginator[T], opts QueryOptions) (PageData, error) {
+	return QueryPageContext(context.Background(), paginator, opts)
+}
+
+// QueryPageContext is QueryPage running the count and the scan under ctx.
+// Cancelling ctx aborts the page with an error; the count alone is also
+// bounded by Config.CountTimeout, after which Total is reported as -1.
+func QueryPageContext[T orm.Tabler](ctx context.Context, paginator Paginator[T], opts QueryOptions) (PageData, error) {
+	config := configOf(paginator)
+	opts, err := config.Limits.apply(opts)
+	if err != nil {
+		return ErrorPage(faults.New(err, &faults.ErrAttr{
+			Code: http.StatusBadRequest,
+		}), opts), err
+	}
+
+	opts.Count = config.countStrategy(opts)
+
+	scope, ok := config.cacheScope(ctx)
+	if !ok || config.PageTTL <= 0 {
+		return queryPage(ctx, paginator, opts)
+	}
+
+	table := paginator.Model().TableName()
+	key := pageCacheKey(scope, opts, paginator.AllowedFields())
+	if cached, ok := config.Cache.Get(table, key); ok {
+		if page, ok := cached.(PageData); ok {
+			if items, ok := page.Items.([]T); ok {
+				// callers may modify their items, never the cached ones
+				page.Items = slices.Clone(items)
+				paginator.SetItems(page.Items.([]T))
+				return page, nil
+			}
+		}
+	}
+
+	page, err := queryPage(ctx, paginator, opts)
+	if err == nil && page.Total >= 0 {
+		stored := page
+		if items, ok := page.Items.([]T); ok {
+			stored.Items = slices.Clone(items)
+		}
+		config.Cache.Set(table, key, stored, config.PageTTL)
+	}
+	return page, err
+}
+
+// InvalidateCache drops every cached page and total of the paginator's
+// table. Call it after writing to the table.
+func InvalidateCache[T orm.Tabler](paginator Paginator[T]) {
+	if cache := configOf(paginator).Cache; cache != nil {
+		cache.Invalidate(paginator.Model().TableName())
+	}
+}
+
+// queryPage runs QueryPageContext once the limits have been applied.
+func queryPage[T orm.Tabler](ctx context.Context, paginator Paginator[T], opts QueryOptions) (PageData, error) {
 	var (
 		model   = paginator.Model()
-		db      = paginator.Adapter().UseModel(model)
+		db      = paginator.Adapter().UseModel(model).WithContext(ctx)
 		allowed = paginator.AllowedFields()
+		config  = configOf(paginator)
 
 		modelType = reflect.TypeOf(model)
+		postgres  = db.Driver() == orm.FlavorPostgres
+		sep       = opts.separator()
 	)
 
+	if err := checkComparisons(opts, allowed, config); err != nil {
+		return ErrorPage(faults.New(err, &faults.ErrAttr{
+			Code: http.StatusBadRequest,
+		}), opts), err
+	}
+
 	for key, val := range opts.Filters {
 		if col, ok := allowed[key]; ok {
-			parts := strings.Split(val, valueSeparator)
+			parts := strings.Split(val, sep)
 			if len(parts) == 1 {
 				db = db.Where(fmt.Sprintf("%s = ?", col), val)
 			} else {
@@ -55,51 +121,15 @@ func QueryPage[T orm.Tabler](paginator Paginator[T], opts QueryOptions) (PageDat
 
 	for _, cmp := range opts.Comparisons {
 		if col, ok := allowed[cmp.Field]; ok {
-			var (
-				parsed     = cmp.Value
-				found      = false
-				foundField reflect.StructField
-			)
-
-			for i := 0; i < modelType.NumField(); i++ {
-				field := modelType.Field(i)
-				jsonTag := field.Tag.Get("json")
-				if jsonTag == cmp.Field {
-					foundField = field
-					found = true
-					break
-				}
-				if jsonTag == "" && strings.EqualFold(field.Name, cmp.Field) {
-					foundField = field
-					found = true
-					break
-				}
+			if cond, args := comparisonClause(col, cmp, isDateField(modelType, cmp.Field), postgres, sep); cond != "" {
+				db = db.Where(cond, args...)
 			}
+		}
+	}
 
-			if found && (foundField.Type.String() == "types.Date" || foundField.Type.String() == "types.Datetime") {
-				if t, err := time.Parse("2006-01-02", cmp.Value); err == nil {
-					switch cmp.Op {
-					case GT:
-						t = t.Add(24 * time.Hour)
-						parsed = t.Format("2006-01-02 15:04:05")
-					case LTE:
-						t = t.Add(24 * time.Hour).Add(-time.Nanosecond)
-						parsed = t.Format("2006-01-02 15:04:05")
-					default:
-						parsed = t.Format("2006-01-02 15:04:05")
-					}
-				}
-			}
-
-			symbol := map[ComparisonOp]string{
-				GT:  ">",
-				GTE: ">=",
-				LT:  "<",
-				LTE: "<=",
-				EQ:  "=",
-			}[cmp.Op]
-
-			db = db.Where(fmt.Sprintf("%s %s ?", col, symbol), parsed)
+	if opts.Filter != nil {
+		if cond, args := filterClause(opts.Filter, allowed, modelType, postgres, sep); cond != "" {
+			db = db.Where(cond, args...)
 		}
 	}
 
@@ -114,7 +144,7 @@ func QueryPage[T orm.Tabler](paginator Paginator[T], opts QueryOptions) (PageDat
 			if col, ok := allowed[field]; ok {
 				cond := fmt.Sprintf("%s LIKE ?", col)
 
-				if db.Driver() == orm.FlavorPostgres {
+				if postgres {
 					cond = fmt.Sprintf("%s ILIKE ?", col)
 				}
 
@@ -133,7 +163,7 @@ func QueryPage[T orm.Tabler](paginator Paginator[T], opts QueryOptions) (PageDat
 			if col, ok := allowed[searchField.Field]; ok && searchField.Keyword != "" {
 				cond := fmt.Sprintf("%s LIKE ?", col)
 
-				if db.Driver() == orm.FlavorPostgres {
+				if postgres {
 					cond = fmt.Sprintf("%s ILIKE ?", col)
 				}
 
@@ -142,6 +172,27 @@ func QueryPage[T orm.Tabler](paginator Paginator[T], opts QueryOptions) (PageDat
 		}
 	}
 
+	var keyset []SortField
+	if opts.Cursor != nil {
+		keyset = keysetFields(opts.Sort, allowed, config.CursorKey)
+		if err := checkKeyset(modelType, keyset); err != nil {
+			return ErrorPage(faults.New(err, &faults.ErrAttr{
+				Code: http.StatusBadRequest,
+			}), opts), err
+		}
+
+		// the keyset columns must be scanned to build the next cursors
+		if len(opts.Select) > 0 {
+			selected := append([]string{}, opts.Select...)
+			for _, f := range keyset {
+				if !slices.Contains(selected, f.Field) {
+					selected = append(selected, f.Field)
+				}
+			}
+			opts.Select = selected
+		}
+	}
+
 	if len(opts.Select) > 0 {
 		columns := []string{}
 		for _, field := range opts.Select {
@@ -182,42 +233,294 @@ func QueryPage[T orm.Tabler](paginator Paginator[T], opts QueryOptions) (PageDat
 		}
 	}
 
-	for _, s := range opts.Sort {
-		if col, ok := allowed[s.Field]; ok {
-			if s.Desc {
-				db = db.Order(col + " DESC")
+	if opts.Cursor != nil {
+		// walking backwards reads the keyset in reverse and flips it later
+		backward := opts.Cursor.Before != ""
+		for _, s := range keyset {
+			if s.Desc != backward {
+				db = db.Order(allowed[s.Field] + " DESC")
 			} else {
-				db = db.Order(col + " ASC")
+				db = db.Order(allowed[s.Field] + " ASC")
+			}
+		}
+	} else {
+		for _, s := range opts.Sort {
+			if col, ok := allowed[s.Field]; ok {
+				if s.Desc {
+					db = db.Order(col + " DESC")
+				} else {
+					db = db.Order(col + " ASC")
+				}
 			}
 		}
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	countCtx, cancel := config.countContext(ctx)
 	defer cancel()
 
-	var total int64
-	countErr := db.WithContext(ctx).Count(&total)
-	if countErr != nil {
-		if faults.Is(countErr, context.DeadlineExceeded) {
-			total = -1
-		} else {
+	var (
+		count    countResult
+		cached   bool
+		countKey string
+		strategy = config.countStrategy(opts)
+	)
+	if scope, ok := config.cacheScope(ctx); ok && config.CountTTL > 0 {
+		opts.Count = strategy // keys count totals of each strategy apart
+		countKey = countCacheKey(scope, opts, allowed)
+		if v, ok := config.Cache.Get(model.TableName(), countKey); ok {
+			count, cached = v.(countResult)
+		}
+	}
+
+	if !cached {
+		var countErr error
+		count, countErr = countRows(countCtx, db, strategy, config.countCap(), postgres)
+		if countErr != nil {
+			// only the count timeout is tolerated, not the caller's deadline
+			if faults.Is(countErr, context.DeadlineExceeded) && ctx.Err() == nil {
+				count = countResult{total: -1, strategy: count.strategy}
+			} else {
+				return PageData{
+					Items: []string{},
+					Total: 0,
+					Page:  opts.Page,
+					Limit: opts.Limit,
+					LastError: faults.New(countErr, &faults.ErrAttr{
+						Code: http.StatusInternalServerError,
+					}),
+				}, countErr
+			}
+		} else if countKey != "" && count.total >= 0 {
+			// unknown totals (-1) are retried on the next request
+			config.Cache.Set(model.TableName(), countKey, count, config.CountTTL)
+		}
+	}
+	total := count.total
+
+	if opts.Cursor != nil {
+		data, err := queryCursorPage(paginator, db, opts, keyset, total)
+		data.CountStrategy, data.TotalCapped = count.strategy, count.capped
+		if err != nil {
+			return data, err
+		}
+		return withMeta(data, opts), nil
+	}
+
+	// without an exact total, one extra row tells whether more follow
+	probe := opts.Limit > 0 && (total < 0 || count.strategy != CountExact)
+
+	items := paginator.Items()
+	if opts.Limit > 0 {
+		limit := opts.Limit
+		if probe {
+			limit++
+		}
+		db = db.Offset(opts.Offset).Limit(limit)
+	}
+
+	if err := db.Scan(&items); err != nil {
+		return PageData{Items: paginator.Items(),
+			Total: total,
+			Page:  opts.Page,
+			Limit: opts.Limit,
+			LastError: faults.New(err, &faults.ErrAttr{
+				Code: http.StatusInternalServerError,
+			})}, err
+	}
+
+	if items == nil {
+		items = []T{}
+	}
+
+	hasMore := opts.Limit > 0 && int64(opts.Offset+len(items)) < total
+	if probe {
+		hasMore = len(items) > opts.Limit
+		if hasMore {
+			items = items[:opts.Limit]
+		}
+	}
+
+	paginator.SetItems(items)
+
+	return withMeta(PageData{
+		Items:         paginator.Items(),
+		Total:         total,
+		Page:          opts.Page,
+		Limit:         opts.Limit,
+		CountStrategy: count.strategy,
+		TotalCapped:   count.capped,
+		HasMore:       hasMore,
+	}, opts), nil
+}
+
+// isDateField reports whether the struct field matching the JSON name (or
+// the field name when untagged) holds a date value.
+func isDateField(modelType reflect.Type, name string) bool {
+	for i := 0; i < modelType.NumField(); i++ {
+		field := modelType.Field(i)
+		jsonTag := field.Tag.Get("json")
+		if jsonTag == name || (jsonTag == "" && strings.EqualFold(field.Name, name)) {
+			return field.Type.String() == "types.Date" || field.Type.String() == "types.Datetime"
+		}
+	}
+	return false
+}
+
+// comparisonClause renders a ComparisonFilter as a SQL condition with its
+// bind arguments. Negative operators (ne, nin) also match NULL columns, the
+// way a reader of `status[ne]=archived` expects. Pattern operators follow the
+// driver flavor: ILIKE and `~` on Postgres, LOWER(...) LIKE and REGEXP
+// elsewhere, and escape with a backslash. An empty condition is returned for
+// malformed comparisons, which are then skipped.
+func comparisonClause(col string, cmp ComparisonFilter, dateField bool, postgres bool, sep string) (string, []any) {
+	value := func(op ComparisonOp, v string) any {
+		if dateField {
+			return dateBound(op, v)
+		}
+		return v
+	}
+
+	list := func(op ComparisonOp) []any {
+		parts := strings.Split(cmp.Value, sep)
+		args := make([]any, len(parts))
+		for i, v := range parts {
+			args[i] = value(op, v)
+		}
+		return args
+	}
+
+	switch cmp.Op {
+	case GT, GTE, LT, LTE, EQ:
+		symbol := map[ComparisonOp]string{
+			GT:  ">",
+			GTE: ">=",
+			LT:  "<",
+			LTE: "<=",
+			EQ:  "=",
+		}[cmp.Op]
+		return fmt.Sprintf("%s %s ?", col, symbol), []any{value(cmp.Op, cmp.Value)}
+	case NE:
+		return fmt.Sprintf("(%s <> ? OR %s IS NULL)", col, col), []any{value(cmp.Op, cmp.Value)}
+	case IN, NIN:
+		args := list(EQ)
+		placeholders := strings.TrimRight(strings.Repeat("?,", len(args)), ",")
+		if cmp.Op == NIN {
+			return fmt.Sprintf("(%s NOT IN (%s) OR %s IS NULL)", col, placeholders, col), args
+		}
+		return fmt.Sprintf("%s IN (%s)", col, placeholders), args
+	case BETWEEN:
+		parts := strings.Split(cmp.Value, sep)
+		if len(parts) != 2 {
+			return "", nil
+		}
+		return fmt.Sprintf("%s BETWEEN ? AND ?", col), []any{value(GTE, parts[0]), value(LTE, parts[1])}
+	case ISNULL, NOTNULL:
+		flag, ok := nullFlag(cmp.Value)
+		if !ok {
+			return "", nil
+		}
+		if flag == (cmp.Op == ISNULL) {
+			return fmt.Sprintf("%s IS NULL", col), nil
+		}
+		return fmt.Sprintf("%s IS NOT NULL", col), nil
+	case LIKE, ILIKE, PREFIX, SUFFIX:
+		// patterns escape with a backslash; MySQL reads '\' as a string
+		// escape, so outside Postgres the escape character is bound
+		args := []any{likePattern(cmp.Value, cmp.Op)}
+		escape := `'\'`
+		if !postgres {
+			escape = "?"
+			args = append(args, `\`)
+		}
+		switch {
+		case cmp.Op == LIKE:
+			return fmt.Sprintf("%s LIKE ? ESCAPE %s", col, escape), args
+		case postgres:
+			return fmt.Sprintf("%s ILIKE ? ESCAPE %s", col, escape), args
+		default:
+			return fmt.Sprintf("LOWER(%s) LIKE LOWER(?) ESCAPE %s", col, escape), args
+		}
+	case REGEX:
+		if postgres {
+			return fmt.Sprintf("%s ~ ?", col), []any{cmp.Value}
+		}
+		return fmt.Sprintf("%s REGEXP ?", col), []any{cmp.Value}
+	default:
+		return "", nil
+	}
+}
+
+// dateBound widens a plain date into the datetime bound matching op, so that
+// `created_at[lte]=2024-01-31` includes the whole last day.
+func dateBound(op ComparisonOp, value string) string {
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return value
+	}
+	switch op {
+	case GT:
+		t = t.Add(24 * time.Hour)
+	case LTE:
+		t = t.Add(24 * time.Hour).Add(-time.Nanosecond)
+	}
+	return t.Format("2006-01-02 15:04:05")
+}
+
+// queryCursorPage finishes a QueryPage in cursor mode. Instead of OFFSET it
+// seeks past the keyset values stored in the cursor and reads one extra row
+// to find out whether another page exists.
+func queryCursorPage[T orm.Tabler](paginator Paginator[T], db orm.QueryAdapter, opts QueryOptions, keyset []SortField, total int64) (PageData, error) {
+	var (
+		allowed  = paginator.AllowedFields()
+		token    = opts.Cursor.After
+		backward = false
+	)
+
+	secret, err := configOf(paginator).cursorSecret()
+	if err != nil {
+		return PageData{
+			Items: []string{},
+			Total: total,
+			Page:  opts.Page,
+			Limit: opts.Limit,
+			LastError: faults.New(err, &faults.ErrAttr{
+				Code: http.StatusInternalServerError,
+			}),
+		}, err
+	}
+
+	if opts.Cursor.Before != "" {
+		token = opts.Cursor.Before
+		backward = true
+	}
+
+	if token != "" && len(keyset) > 0 {
+		cursor, err := decodeCursor(secret, token, keyset)
+		if err != nil {
 			return PageData{
 				Items: []string{},
-				Total: 0,
+				Total: total,
 				Page:  opts.Page,
 				Limit: opts.Limit,
-				LastError: faults.New(countErr, &faults.ErrAttr{
-					Code: http.StatusInternalServerError,
+				LastError: faults.New(err, &faults.ErrAttr{
+					Code: http.StatusBadRequest,
 				}),
-			}, countErr
+			}, err
+		}
+
+		columns := make([]string, len(keyset))
+		for i, f := range keyset {
+			columns[i] = allowed[f.Field]
 		}
+		cond, args := keysetCondition(columns, keyset, cursor.Values, backward)
+		db = db.Where(cond, args...)
 	}
 
-	items := paginator.Items()
 	if opts.Limit > 0 {
-		db = db.Offset(opts.Offset).Limit(opts.Limit)
+		db = db.Limit(opts.Limit + 1)
 	}
 
+	items := paginator.Items()
 	if err := db.Scan(&items); err != nil {
 		return PageData{Items: paginator.Items(),
 			Total: total,
@@ -232,12 +535,80 @@ func QueryPage[T orm.Tabler](paginator Paginator[T], opts QueryOptions) (PageDat
 		items = []T{}
 	}
 
+	hasMore := opts.Limit > 0 && len(items) > opts.Limit
+	if hasMore {
+		items = items[:opts.Limit]
+	}
+	if backward {
+		slices.Reverse(items)
+	}
+
 	paginator.SetItems(items)
 
-	return PageData{Items: paginator.Items(), Total: total, Page: opts.Page, Limit: opts.Limit}, nil
+	// walking backwards always leaves the page the walk started from ahead
+	data := PageData{Items: paginator.Items(), Total: total, Page: opts.Page, Limit: opts.Limit, HasMore: hasMore || backward}
+	if len(items) > 0 && len(keyset) > 0 {
+		first := encodeCursor(secret, keyset, cursorValues(items[0], keyset), 0)
+		last := encodeCursor(secret, keyset, cursorValues(items[len(items)-1], keyset), 0)
+
+		if backward {
+			data.NextCursor = last
+			if hasMore {
+				data.PrevCursor = first
+			}
+		} else {
+			if hasMore {
+				data.NextCursor = last
+			}
+			if token != "" {
+				data.PrevCursor = first
+			}
+		}
+	}
+
+	return data, nil
 }
 
 func DownloadPage[T orm.Tabler](paginator Paginator[T], opts QueryOptions) (PageData, error) {
+	return DownloadPageContext(context.Background(), paginator, opts)
+}
+
+// DownloadPageContext is DownloadPage running the count and the scan under
+// ctx, like QueryPageContext.
+func DownloadPageContext[T orm.Tabler](ctx context.Context, paginator Paginator[T], opts QueryOptions) (PageData, error) {
+	var (
+		limits  = configOf(paginator).Limits
+		maxRows = limits.MaxDownloadRows
+	)
+
+	// the page size and depth limits do not apply to downloads
+	limits.MaxLimit, limits.MaxPage = 0, 0
+	opts, err := limits.apply(opts)
+	if err != nil {
+		return ErrorPage(faults.New(err, &faults.ErrAttr{
+			Code: http.StatusBadRequest,
+		}), opts), err
+	}
+
 	opts.Limit = -1
-	return QueryPage(paginator, opts)
+	if maxRows > 0 {
+		opts.Offset, opts.Limit = 0, maxRows
+		if limits.Mode == LimitReject {
+			// read one extra row to detect downloads over the maximum
+			opts.Limit = maxRows + 1
+		}
+	}
+
+	data, err := queryPage(ctx, paginator, opts)
+	if err != nil || maxRows <= 0 || limits.Mode != LimitReject {
+		return data, err
+	}
+	if data.Total > int64(maxRows) || reflect.ValueOf(data.Items).Len() > maxRows {
+		err = fmt.Errorf("%w: download rows exceed %d", ErrLimitExceeded, maxRows)
+		return ErrorPage(faults.New(err, &faults.ErrAttr{
+			Code: http.StatusBadRequest,
+		}), opts), err
+	}
+	data.Limit = maxRows
+	return withMeta(data, opts), nil
 }
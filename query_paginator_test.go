@@ -0,0 +1,123 @@
+package slicer
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestComparisonClause(t *testing.T) {
+	tests := []struct {
+		name     string
+		cmp      ComparisonFilter
+		date     bool
+		postgres bool
+		wantCond string
+		wantArgs []any
+	}{
+		{"gt", ComparisonFilter{Field: "age", Op: GT, Value: "30"}, false, false, "age > ?", []any{"30"}},
+		{"ne includes nulls", ComparisonFilter{Field: "status", Op: NE, Value: "archived"}, false, false, "(status <> ? OR status IS NULL)", []any{"archived"}},
+		{"in", ComparisonFilter{Field: "id", Op: IN, Value: "1,2,3"}, false, false, "id IN (?,?,?)", []any{"1", "2", "3"}},
+		{"nin includes nulls", ComparisonFilter{Field: "id", Op: NIN, Value: "1,2"}, false, false, "(id NOT IN (?,?) OR id IS NULL)", []any{"1", "2"}},
+		{"between", ComparisonFilter{Field: "age", Op: BETWEEN, Value: "18,65"}, false, false, "age BETWEEN ? AND ?", []any{"18", "65"}},
+		{"between needs two bounds", ComparisonFilter{Field: "age", Op: BETWEEN, Value: "18"}, false, false, "", nil},
+		{"between dates spans whole days", ComparisonFilter{Field: "day", Op: BETWEEN, Value: "2024-01-01,2024-01-31"}, true, false, "day BETWEEN ? AND ?", []any{"2024-01-01 00:00:00", "2024-01-31 23:59:59"}},
+		{"isnull", ComparisonFilter{Field: "deleted_at", Op: ISNULL, Value: "true"}, false, false, "deleted_at IS NULL", nil},
+		{"isnull false", ComparisonFilter{Field: "deleted_at", Op: ISNULL, Value: "false"}, false, false, "deleted_at IS NOT NULL", nil},
+		{"notnull", ComparisonFilter{Field: "deleted_at", Op: NOTNULL}, false, false, "deleted_at IS NOT NULL", nil},
+		{"isnull needs a boolean", ComparisonFilter{Field: "deleted_at", Op: ISNULL, Value: "maybe"}, false, false, "", nil},
+		{"unknown operator", ComparisonFilter{Field: "age", Op: "near", Value: "1"}, false, false, "", nil},
+		{"like", ComparisonFilter{Field: "name", Op: LIKE, Value: "Jo%"}, false, false, "name LIKE ? ESCAPE ?", []any{"Jo%", `\`}},
+		{"ilike mysql", ComparisonFilter{Field: "name", Op: ILIKE, Value: "jo%"}, false, false, "LOWER(name) LIKE LOWER(?) ESCAPE ?", []any{"jo%", `\`}},
+		{"ilike postgres", ComparisonFilter{Field: "name", Op: ILIKE, Value: "jo%"}, false, true, `name ILIKE ? ESCAPE '\'`, []any{"jo%"}},
+		{"prefix escapes wildcards", ComparisonFilter{Field: "code", Op: PREFIX, Value: "A_1%"}, false, true, `code ILIKE ? ESCAPE '\'`, []any{`A\_1\%%`}},
+		{"prefix mysql ignores case", ComparisonFilter{Field: "code", Op: PREFIX, Value: "A_1"}, false, false, "LOWER(code) LIKE LOWER(?) ESCAPE ?", []any{`A\_1%`, `\`}},
+		{"suffix mysql ignores case", ComparisonFilter{Field: "email", Op: SUFFIX, Value: "@corp.com"}, false, false, "LOWER(email) LIKE LOWER(?) ESCAPE ?", []any{"%@corp.com", `\`}},
+		{"like postgres", ComparisonFilter{Field: "name", Op: LIKE, Value: `%\_bot`}, false, true, `name LIKE ? ESCAPE '\'`, []any{`%\_bot`}},
+		{"regex postgres", ComparisonFilter{Field: "code", Op: REGEX, Value: `^A\d+$`}, false, true, "code ~ ?", []any{`^A\d+$`}},
+		{"regex mysql", ComparisonFilter{Field: "code", Op: REGEX, Value: `^A\d+$`}, false, false, "code REGEXP ?", []any{`^A\d+$`}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cond, args := comparisonClause(tt.cmp.Field, tt.cmp, tt.date, tt.postgres, ",")
+			if cond != tt.wantCond {
+				t.Errorf("Expected condition %q, got %q", tt.wantCond, cond)
+			}
+			if len(args) != 0 || len(tt.wantArgs) != 0 {
+				if !reflect.DeepEqual(args, tt.wantArgs) {
+					t.Errorf("Expected args %v, got %v", tt.wantArgs, args)
+				}
+			}
+		})
+	}
+}
+
+func TestFilterClause(t *testing.T) {
+	allowed := map[string]string{"status": "tasks.status", "owner": "tasks.owner"}
+	modelType := reflect.TypeOf(struct {
+		Status string `json:"status"`
+		Owner  string `json:"owner"`
+	}{})
+
+	t.Run("Nested groups", func(t *testing.T) {
+		expr := And(
+			Or(Compare("status", EQ, "open"), Compare("status", EQ, "pending")),
+			Not(Compare("owner", EQ, "bot")),
+		)
+		cond, args := filterClause(expr, allowed, modelType, false, ",")
+
+		want := "((tasks.status = ? OR tasks.status = ?) AND NOT (tasks.owner = ?))"
+		if cond != want {
+			t.Errorf("Expected %q, got %q", want, cond)
+		}
+		if !reflect.DeepEqual(args, []any{"open", "pending", "bot"}) {
+			t.Errorf("Unexpected args: %v", args)
+		}
+	})
+
+	t.Run("Disallowed fields are dropped", func(t *testing.T) {
+		expr := Or(Compare("secret", EQ, "x"), Compare("status", EQ, "open"))
+		cond, _ := filterClause(expr, allowed, modelType, false, ",")
+
+		if cond != "(tasks.status = ?)" {
+			t.Errorf("Unexpected condition: %q", cond)
+		}
+
+		cond, _ = filterClause(Not(Compare("secret", EQ, "x")), allowed, modelType, false, ",")
+		if cond != "" {
+			t.Errorf("Expected empty condition, got %q", cond)
+		}
+	})
+}
+
+func TestCountContext(t *testing.T) {
+	parent, cancel := context.WithTimeout(context.Background(), time.Hour)
+	defer cancel()
+
+	tests := []struct {
+		name    string
+		timeout time.Duration
+		want    time.Duration
+	}{
+		{"default", 0, 3 * time.Second},
+		{"configured", 50 * time.Millisecond, 50 * time.Millisecond},
+		{"caller only", -1, time.Hour},
+	}
+	for _, tt := range tests {
+		ctx, cancel := Config{CountTimeout: tt.timeout}.countContext(parent)
+		deadline, ok := ctx.Deadline()
+		if left := time.Until(deadline); !ok || left > tt.want || left < tt.want-time.Second {
+			t.Errorf("%s: expected a deadline in %v, got %v", tt.name, tt.want, left)
+		}
+		cancel()
+	}
+
+	ctx, cancelCount := Config{}.countContext(parent)
+	defer cancelCount()
+	cancel()
+	if ctx.Err() == nil {
+		t.Errorf("Expected cancelling the caller to cancel the count")
+	}
+}
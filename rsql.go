@@ -0,0 +1,334 @@
+package slicer
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// rsqlOperators maps RSQL/FIQL comparison operators to ComparisonOp. Custom
+// `=op=` operators named after a ComparisonOp (e.g. `=between=`,
+// `=isnull=`, `=prefix=`) are accepted as well.
+var rsqlOperators = map[string]ComparisonOp{
+	"==":    EQ,
+	"!=":    NE,
+	">":     GT,
+	">=":    GTE,
+	"<":     LT,
+	"<=":    LTE,
+	"=gt=":  GT,
+	"=ge=":  GTE,
+	"=lt=":  LT,
+	"=le=":  LTE,
+	"=in=":  IN,
+	"=out=": NIN,
+}
+
+// ParseRSQL parses an RSQL/FIQL query held in the `q` parameter, e.g.
+//
+//	q=status==open;priority=gt=3,owner==alice
+//
+// where `;` (or `and`) binds tighter than `,` (or `or`) and parentheses group
+// constraints. Unquoted `==` and `!=` values containing `*` become LIKE
+// patterns; `!=` patterns match NULL, as `!=` does. Every other parameter
+// (page, limit, sort, ...) is handled by ParseOpts, and the RSQL expression
+// is stored in QueryOptions.Filter, ANDed with any `filter` expression.
+// Malformed queries return a *SyntaxError.
+func ParseRSQL(values url.Values) (QueryOptions, error) {
+	return defaultParser().ParseRSQL(values)
+}
+
+// ParseRSQL is the package ParseRSQL using the parser's settings. The query
+// is read from Params.Query; operators the parser does not accept return
+// ErrUnsupportedOperator.
+func (p *Parser) ParseRSQL(values url.Values) (QueryOptions, error) {
+	name := p.params().Query
+	rest := url.Values{}
+	for key, val := range values {
+		if key != name {
+			rest[key] = val
+		}
+	}
+	opts := p.Parse(rest)
+
+	q := values.Get(name)
+	if strings.TrimSpace(q) == "" {
+		return opts, nil
+	}
+
+	rp := &rsqlParser{input: q, sep: p.separator()}
+	expr, err := rp.parseOr()
+	if err != nil {
+		return opts, err
+	}
+	rp.skipSpace()
+	if rp.pos < len(rp.input) {
+		return opts, rp.errorf("unexpected %q", rp.input[rp.pos])
+	}
+	if err := p.checkOperators(expr); err != nil {
+		return opts, err
+	}
+	p.normalizeExpr(expr)
+
+	if opts.Filter != nil {
+		expr = And(opts.Filter, expr)
+	}
+	opts.Filter = expr
+	return opts, nil
+}
+
+type rsqlParser struct {
+	input string
+	pos   int
+	sep   string
+	depth int
+}
+
+func (p *rsqlParser) errorf(format string, args ...any) error {
+	return &SyntaxError{Input: p.input, Pos: p.pos + 1, Msg: fmt.Sprintf(format, args...)}
+}
+
+func (p *rsqlParser) skipSpace() {
+	for p.pos < len(p.input) && (p.input[p.pos] == ' ' || p.input[p.pos] == '\t') {
+		p.pos++
+	}
+}
+
+// separator consumes the symbol or keyword joining two constraints.
+func (p *rsqlParser) separator(symbol byte, keyword string) bool {
+	start := p.pos
+	p.skipSpace()
+	if p.pos < len(p.input) && p.input[p.pos] == symbol {
+		p.pos++
+		return true
+	}
+
+	// keywords must be surrounded by blanks: `a==1 and b==2`
+	if p.pos > start && strings.HasPrefix(p.input[p.pos:], keyword) {
+		end := p.pos + len(keyword)
+		if end < len(p.input) && (p.input[end] == ' ' || p.input[end] == '(') {
+			p.pos = end
+			return true
+		}
+	}
+	p.pos = start
+	return false
+}
+
+func (p *rsqlParser) parseOr() (*FilterExpr, error) {
+	return p.parseGroup(LogicOr, ',', "or", p.parseAnd)
+}
+
+func (p *rsqlParser) parseAnd() (*FilterExpr, error) {
+	return p.parseGroup(LogicAnd, ';', "and", p.parseConstraint)
+}
+
+func (p *rsqlParser) parseGroup(op LogicOp, symbol byte, keyword string, next func() (*FilterExpr, error)) (*FilterExpr, error) {
+	first, err := next()
+	if err != nil {
+		return nil, err
+	}
+
+	children := []*FilterExpr{first}
+	for p.separator(symbol, keyword) {
+		child, err := next()
+		if err != nil {
+			return nil, err
+		}
+		children = append(children, child)
+	}
+
+	if len(children) == 1 {
+		return first, nil
+	}
+	return &FilterExpr{Op: op, Children: children}, nil
+}
+
+func (p *rsqlParser) parseConstraint() (*FilterExpr, error) {
+	p.skipSpace()
+	if p.pos < len(p.input) && p.input[p.pos] == '(' {
+		if p.depth == maxFilterDepth {
+			return nil, p.errorf("query nested deeper than %d levels", maxFilterDepth)
+		}
+		p.depth++
+		defer func() { p.depth-- }()
+
+		p.pos++
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		p.skipSpace()
+		if p.pos >= len(p.input) || p.input[p.pos] != ')' {
+			return nil, p.errorf("expected ')'")
+		}
+		p.pos++
+		return expr, nil
+	}
+
+	field, err := p.selector()
+	if err != nil {
+		return nil, err
+	}
+
+	opPos := p.pos
+	op, err := p.operator()
+	if err != nil {
+		return nil, err
+	}
+
+	args, quoted, list, err := p.arguments()
+	if err != nil {
+		return nil, err
+	}
+
+	switch op {
+	case IN, NIN:
+	case BETWEEN:
+		if len(args) != 2 {
+			p.pos = opPos
+			return nil, p.errorf("between takes exactly two values")
+		}
+	default:
+		if list {
+			p.pos = opPos
+			return nil, p.errorf("%s takes a single value", op)
+		}
+	}
+
+	// unquoted wildcards turn equality into a LIKE pattern
+	if (op == EQ || op == NE) && !quoted && strings.Contains(args[0], "*") {
+		parts := strings.Split(args[0], "*")
+		for i := range parts {
+			parts[i] = escapeLike(parts[i])
+		}
+		like := Compare(field, LIKE, strings.Join(parts, "%"))
+		if op == NE {
+			// like ne, the negated pattern also matches NULL
+			return Or(Not(like), Compare(field, ISNULL, "")), nil
+		}
+		return like, nil
+	}
+
+	return Compare(field, op, strings.Join(args, p.sep)), nil
+}
+
+func (p *rsqlParser) selector() (string, error) {
+	p.skipSpace()
+	start := p.pos
+	for p.pos < len(p.input) {
+		c := p.input[p.pos]
+		if !(c == '_' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z') {
+			break
+		}
+		p.pos++
+	}
+	if start == p.pos {
+		if p.pos >= len(p.input) {
+			return "", p.errorf("expected field name, found end of input")
+		}
+		return "", p.errorf("expected field name, found %q", p.input[p.pos])
+	}
+	return p.input[start:p.pos], nil
+}
+
+func (p *rsqlParser) operator() (ComparisonOp, error) {
+	rest := p.input[p.pos:]
+
+	if strings.HasPrefix(rest, "=") && !strings.HasPrefix(rest, "==") {
+		if end := strings.IndexByte(rest[1:], '='); end > 0 {
+			name := rest[:end+2]
+			if op, ok := rsqlOperators[name]; ok {
+				p.pos += len(name)
+				return op, nil
+			}
+			if op := ComparisonOp(name[1 : len(name)-1]); comparisonOps[op] {
+				p.pos += len(name)
+				return op, nil
+			}
+			return "", p.errorf("unknown operator %q", name)
+		}
+	}
+
+	for _, name := range []string{"==", "!=", ">=", "<=", ">", "<"} {
+		if strings.HasPrefix(rest, name) {
+			p.pos += len(name)
+			return rsqlOperators[name], nil
+		}
+	}
+	return "", p.errorf("expected comparison operator")
+}
+
+// arguments reads a single value or a parenthesised value list. quoted
+// reports whether a single value was quoted.
+func (p *rsqlParser) arguments() ([]string, bool, bool, error) {
+	if p.pos < len(p.input) && p.input[p.pos] == '(' {
+		p.pos++
+		var values []string
+		for {
+			p.skipSpace()
+			start := p.pos
+			v, _, err := p.value()
+			if err != nil {
+				return nil, false, true, err
+			}
+			// lists are joined with the separator, so values cannot hold it
+			if strings.Contains(v, p.sep) {
+				p.pos = start
+				return nil, false, true, p.errorf("list values must not contain %q", p.sep)
+			}
+			values = append(values, v)
+			p.skipSpace()
+			if p.pos < len(p.input) && p.input[p.pos] == ',' {
+				p.pos++
+				continue
+			}
+			if p.pos < len(p.input) && p.input[p.pos] == ')' {
+				p.pos++
+				return values, false, true, nil
+			}
+			return nil, false, true, p.errorf("expected ',' or ')' in value list")
+		}
+	}
+
+	v, quoted, err := p.value()
+	return []string{v}, quoted, false, err
+}
+
+func (p *rsqlParser) value() (string, bool, error) {
+	if p.pos < len(p.input) && (p.input[p.pos] == '\'' || p.input[p.pos] == '"') {
+		quote := p.input[p.pos]
+		start := p.pos
+		p.pos++
+
+		var b strings.Builder
+		for p.pos < len(p.input) {
+			c := p.input[p.pos]
+			switch {
+			case c == '\\' && p.pos+1 < len(p.input):
+				b.WriteByte(p.input[p.pos+1])
+				p.pos += 2
+			case c == quote:
+				p.pos++
+				return b.String(), true, nil
+			default:
+				b.WriteByte(c)
+				p.pos++
+			}
+		}
+		p.pos = start
+		return "", true, p.errorf("unterminated quoted value")
+	}
+
+	start := p.pos
+	for p.pos < len(p.input) && !strings.ContainsRune(` "'();,=!~<>`, rune(p.input[p.pos])) {
+		p.pos++
+	}
+	if start == p.pos {
+		if p.pos >= len(p.input) {
+			return "", false, p.errorf("expected value, found end of input")
+		}
+		return "", false, p.errorf("expected value, found %q", p.input[p.pos])
+	}
+	return p.input[start:p.pos], false, nil
+}
@@ -2,15 +2,19 @@ package slicer
 
 import (
 	"fmt"
+	"net/http"
 	"reflect"
 	"sort"
 	"strings"
+
+	"github.com/godev90/validator/faults"
 )
 
 type SlicePaginator[T any] struct {
 	source []T
 	items  []T
 	fields map[string]string
+	config Config
 }
 
 func NewSlicePaginator[T any](source []T, allowedFields map[string]string) *SlicePaginator[T] {
@@ -37,30 +41,71 @@ func (p *SlicePaginator[T]) SetItems(items []T) {
 
 // SetItems sets the paginator's items to the provided slice. This is used by
 // pagination routines to store the resulting page.
+func (p *SlicePaginator[T]) Config() Config {
+	return p.config
+}
+
+// Config returns the optional pagination settings of the paginator.
+func (p *SlicePaginator[T]) Configure(config Config) *SlicePaginator[T] {
+	p.config = config
+	return p
+}
+
+// Configure replaces the paginator's optional settings and returns the
+// paginator to allow chaining after NewSlicePaginator.
+
+func (p *SlicePaginator[T]) AllowedFields() map[string]string {
+	return p.fields
+}
+
+// AllowedFields returns the map of logical field names to columns the
+// paginator was created with.
 func SlicePage[T any](p *SlicePaginator[T], opts QueryOptions) (PageData, error) {
 	var filtered []T
 
-	opts.Offset = (opts.Page - 1) * opts.Limit
+	opts, err := p.config.Limits.apply(opts)
+	if err != nil {
+		return ErrorPage(faults.New(err, &faults.ErrAttr{
+			Code: http.StatusBadRequest,
+		}), opts), err
+	}
+
+	if opts.Offset == 0 {
+		opts.Offset = (opts.Page - 1) * opts.Limit
+	}
+
+	if err := checkComparisons(opts, p.fields, p.config); err != nil {
+		return ErrorPage(faults.New(err, &faults.ErrAttr{
+			Code: http.StatusBadRequest,
+		}), opts), err
+	}
+
+	// fields are read through registered accessors, else reflection
+	access := accessorsOf[T]()
 
 	// 1. Apply ComparisonFilters
 	for _, item := range p.source {
 		match := true
-		v := reflect.ValueOf(item)
-		if v.Kind() == reflect.Ptr {
-			v = v.Elem()
-		}
 
 		for _, cmp := range opts.Comparisons {
 			if _, ok := p.fields[cmp.Field]; !ok {
 				continue
 			}
-			field := findFieldByColumn(v, cmp.Field)
-			if !field.IsValid() || !compare(field.Interface(), cmp.Value, cmp.Op) {
+			field, ok := access.field(item, cmp.Field)
+			if !ok || !compareWith(field, cmp.Value, cmp.Op, opts.separator()) {
 				match = false
 				break
 			}
 		}
 
+		// 1.1. Apply the filter expression tree
+		if match && opts.Filter != nil {
+			truth, _ := evalFilter(func(column string) (any, bool) {
+				return access.field(item, column)
+			}, opts.Filter, p.fields, opts.separator())
+			match = truth == truthTrue
+		}
+
 		if match {
 			filtered = append(filtered, item)
 		}
@@ -69,21 +114,17 @@ func SlicePage[T any](p *SlicePaginator[T], opts QueryOptions) (PageData, error)
 	if len(opts.Filters) > 0 {
 		var temp []T
 		for _, item := range filtered {
-			v := reflect.ValueOf(item)
-			if v.Kind() == reflect.Ptr {
-				v = v.Elem()
-			}
 			match := true
 			for field, val := range opts.Filters {
 				if _, ok := p.fields[field]; !ok {
 					continue
 				}
-				f := findFieldByColumn(v, field)
-				if !f.IsValid() {
+				f, ok := access.field(item, field)
+				if !ok {
 					match = false
 					break
 				}
-				actual := fmt.Sprintf("%v", f.Interface())
+				actual := fmt.Sprintf("%v", f)
 				if actual != val {
 					match = false
 					break
@@ -100,18 +141,13 @@ func SlicePage[T any](p *SlicePaginator[T], opts QueryOptions) (PageData, error)
 	if opts.Search != nil {
 		var searched []T
 		for _, item := range filtered {
-			v := reflect.ValueOf(item)
-			if v.Kind() == reflect.Ptr {
-				v = v.Elem()
-			}
 			matched := false
 			for _, key := range opts.Search.Fields {
 				if _, ok := p.fields[key]; !ok {
 					continue
 				}
-				field := findFieldByColumn(v, key)
-				if field.IsValid() {
-					val := fmt.Sprintf("%v", field.Interface())
+				if field, ok := access.field(item, key); ok {
+					val := fmt.Sprintf("%v", field)
 					if strings.Contains(strings.ToLower(val), strings.ToLower(opts.Search.Keyword)) {
 						matched = true
 						break
@@ -129,18 +165,13 @@ func SlicePage[T any](p *SlicePaginator[T], opts QueryOptions) (PageData, error)
 	if opts.SearchAnd != nil && len(opts.SearchAnd.Fields) > 0 {
 		var searchedAnd []T
 		for _, item := range filtered {
-			v := reflect.ValueOf(item)
-			if v.Kind() == reflect.Ptr {
-				v = v.Elem()
-			}
 			matchedAll := true
 			for _, searchField := range opts.SearchAnd.Fields {
 				if _, ok := p.fields[searchField.Field]; !ok {
 					continue
 				}
-				field := findFieldByColumn(v, searchField.Field)
-				if field.IsValid() {
-					val := fmt.Sprintf("%v", field.Interface())
+				if field, ok := access.field(item, searchField.Field); ok {
+					val := fmt.Sprintf("%v", field)
 					if !strings.Contains(strings.ToLower(val), strings.ToLower(searchField.Keyword)) {
 						matchedAll = false
 						break
@@ -157,27 +188,35 @@ func SlicePage[T any](p *SlicePaginator[T], opts QueryOptions) (PageData, error)
 		filtered = searchedAnd
 	}
 
-	// 4. Sorting
-	for i := len(opts.Sort) - 1; i >= 0; i-- {
-		sortField := opts.Sort[i]
+	// 4. Sorting. In cursor mode the keyset (sort fields plus tiebreaker)
+	// defines the order so that tokens resolve to a stable position.
+	sortFields := opts.Sort
+	var keyset []SortField
+	if opts.Cursor != nil {
+		keyset = keysetFields(opts.Sort, p.fields, p.config.CursorKey)
+		if err := checkKeyset(reflect.TypeFor[T](), keyset); err != nil {
+			return ErrorPage(faults.New(err, &faults.ErrAttr{
+				Code: http.StatusBadRequest,
+			}), opts), err
+		}
+		sortFields = keyset
+	}
+
+	for i := len(sortFields) - 1; i >= 0; i-- {
+		sortField := sortFields[i]
 		if _, ok := p.fields[sortField.Field]; !ok {
 			continue
 		}
 
 		sort.SliceStable(filtered, func(i, j int) bool {
-			vi := reflect.ValueOf(filtered[i])
-			vj := reflect.ValueOf(filtered[j])
-			if vi.Kind() == reflect.Ptr {
-				vi = vi.Elem()
-				vj = vj.Elem()
-			}
-			fi := findFieldByColumn(vi, sortField.Field)
-			fj := findFieldByColumn(vj, sortField.Field)
-
-			return compareSort(fi.Interface(), fj.Interface(), sortField.Desc)
+			return access.less(filtered[i], filtered[j], sortField.Field, sortField.Desc)
 		})
 	}
 
+	if opts.Cursor != nil {
+		return sliceCursorPage(p, filtered, opts, keyset)
+	}
+
 	// 5. Pagination
 	total := len(filtered)
 	start := opts.Offset
@@ -195,16 +234,132 @@ func SlicePage[T any](p *SlicePaginator[T], opts QueryOptions) (PageData, error)
 		p.SetItems([]T{})
 	}
 
-	return PageData{
-		Items: p.Items(),
-		Total: int64(total),
-		Page:  opts.Page,
-		Limit: opts.Limit,
-	}, nil
+	return withMeta(PageData{
+		Items:         p.Items(),
+		Total:         int64(total),
+		Page:          opts.Page,
+		Limit:         opts.Limit,
+		CountStrategy: CountExact,
+		HasMore:       end < total,
+	}, opts), nil
 }
 
 // SlicePage applies the provided QueryOptions to the paginator's source data
 // and returns a PageData containing the resulting page slice, total count,
-// and pagination metadata. The function performs comparisons, filters,
-// search (including search AND), sorting and pagination in that order. The
+// and pagination metadata. The function performs comparisons, the filter
+// expression, filters, search (including search AND), sorting and pagination
+// in that order. The
 // paginator's items are updated with the selected page slice.
+
+// sliceCursorPage selects the page of the filtered and sorted items that
+// follows (or precedes) the position encoded in opts.Cursor. Because the
+// keyset of an in-memory slice may not be unique, tokens also record how many
+// items with identical keyset values were already consumed.
+func sliceCursorPage[T any](p *SlicePaginator[T], filtered []T, opts QueryOptions, keyset []SortField) (PageData, error) {
+	var (
+		total = len(filtered)
+		token = opts.Cursor.After
+		start = 0
+		end   = total
+	)
+
+	secret, err := p.config.cursorSecret()
+	if err != nil {
+		return PageData{
+			Items: []T{},
+			Total: int64(total),
+			Page:  opts.Page,
+			Limit: opts.Limit,
+			LastError: faults.New(err, &faults.ErrAttr{
+				Code: http.StatusInternalServerError,
+			}),
+		}, err
+	}
+
+	backward := opts.Cursor.Before != ""
+	if backward {
+		token = opts.Cursor.Before
+	}
+
+	if token != "" {
+		cursor, err := decodeCursor(secret, token, keyset)
+		if err != nil {
+			return PageData{
+				Items: []T{},
+				Total: int64(total),
+				Page:  opts.Page,
+				Limit: opts.Limit,
+				LastError: faults.New(err, &faults.ErrAttr{
+					Code: http.StatusBadRequest,
+				}),
+			}, err
+		}
+
+		// position of the first item not sorting before the cursor values
+		pos := sort.Search(total, func(i int) bool {
+			return keysetCompare(filtered[i], keyset, cursor.Values) >= 0
+		})
+		for i := 0; i < cursor.Ties && pos < total; i++ {
+			if keysetCompare(filtered[pos], keyset, cursor.Values) != 0 {
+				break
+			}
+			pos++
+		}
+
+		if backward {
+			end = pos
+		} else {
+			start = pos
+		}
+	}
+
+	if opts.Limit > 0 {
+		if backward {
+			start = max(end-opts.Limit, 0)
+		} else {
+			end = min(start+opts.Limit, total)
+		}
+	}
+
+	pageItems := filtered[start:end]
+	p.SetItems(pageItems)
+	if p.Items() == nil {
+		p.SetItems([]T{})
+	}
+
+	data := PageData{
+		Items:         p.Items(),
+		Total:         int64(total),
+		Page:          opts.Page,
+		Limit:         opts.Limit,
+		CountStrategy: CountExact,
+		HasMore:       end < total,
+	}
+
+	if start < end {
+		if end < total {
+			values, ties := sliceCursorPosition(filtered, end-1, keyset)
+			data.NextCursor = encodeCursor(secret, keyset, values, ties+1)
+		}
+		if start > 0 {
+			values, ties := sliceCursorPosition(filtered, start, keyset)
+			data.PrevCursor = encodeCursor(secret, keyset, values, ties)
+		}
+	}
+
+	return withMeta(data, opts), nil
+}
+
+// sliceCursorPosition returns the keyset values of items[i] and the number
+// of preceding items sharing those values.
+func sliceCursorPosition[T any](items []T, i int, keyset []SortField) ([]string, int) {
+	values := cursorValues(items[i], keyset)
+	ties := 0
+	for j := i - 1; j >= 0; j-- {
+		if keysetCompare(items[j], keyset, values) != 0 {
+			break
+		}
+		ties++
+	}
+	return values, ties
+}
@@ -0,0 +1,202 @@
+package slicer
+
+import (
+	"cmp"
+	"slices"
+)
+
+// The helpers below never modify their input: functions returning a slice
+// always return a new one, empty rather than nil when nothing is left.
+
+// Pair holds the elements Zip takes from the same position of two slices.
+type Pair[A, B any] struct {
+	First  A
+	Second B
+}
+
+// Filter returns the elements of s for which keep returns true.
+func Filter[T any](s []T, keep func(T) bool) []T {
+	out := make([]T, 0, len(s))
+	for _, v := range s {
+		if keep(v) {
+			out = append(out, v)
+		}
+	}
+	return slices.Clip(out)
+}
+
+// Map returns the result of fn for every element of s.
+func Map[T, U any](s []T, fn func(T) U) []U {
+	out := make([]U, len(s))
+	for i, v := range s {
+		out[i] = fn(v)
+	}
+	return out
+}
+
+// Reduce folds s into a single value, calling fn with the accumulated value
+// and each element in turn, starting from initial.
+func Reduce[T, A any](s []T, initial A, fn func(A, T) A) A {
+	acc := initial
+	for _, v := range s {
+		acc = fn(acc, v)
+	}
+	return acc
+}
+
+// Any reports whether pred holds for at least one element of s.
+func Any[T any](s []T, pred func(T) bool) bool {
+	return slices.ContainsFunc(s, pred)
+}
+
+// All reports whether pred holds for every element of s. It is true for an
+// empty slice.
+func All[T any](s []T, pred func(T) bool) bool {
+	for _, v := range s {
+		if !pred(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// Find returns the first element of s for which pred holds.
+func Find[T any](s []T, pred func(T) bool) (T, bool) {
+	if i := slices.IndexFunc(s, pred); i >= 0 {
+		return s[i], true
+	}
+	var zero T
+	return zero, false
+}
+
+// Exists reports whether v is an element of s.
+func Exists[T comparable](s []T, v T) bool {
+	return slices.Contains(s, v)
+}
+
+// Chunk splits s into consecutive slices of size elements, the last one
+// holding the remainder. The chunks are copies of s. It panics if size is
+// less than 1.
+func Chunk[T any](s []T, size int) [][]T {
+	if size < 1 {
+		panic("slicer: chunk size must be positive")
+	}
+	out := make([][]T, 0, (len(s)+size-1)/size)
+	for start := 0; start < len(s); start += size {
+		end := min(start+size, len(s))
+		out = append(out, slices.Clone(s[start:end]))
+	}
+	return out
+}
+
+// Unique returns the elements of s without duplicates, keeping the first
+// occurrence of each.
+func Unique[T comparable](s []T) []T {
+	seen := make(map[T]struct{}, len(s))
+	out := make([]T, 0, len(s))
+	for _, v := range s {
+		if _, ok := seen[v]; !ok {
+			seen[v] = struct{}{}
+			out = append(out, v)
+		}
+	}
+	return slices.Clip(out)
+}
+
+// Reverse returns the elements of s in reverse order.
+func Reverse[T any](s []T) []T {
+	out := make([]T, len(s))
+	for i, v := range s {
+		out[len(s)-1-i] = v
+	}
+	return out
+}
+
+// GroupBy groups the elements of s by the key returned by key, keeping their
+// order within each group.
+func GroupBy[T any, K comparable](s []T, key func(T) K) map[K][]T {
+	out := make(map[K][]T)
+	for _, v := range s {
+		k := key(v)
+		out[k] = append(out[k], v)
+	}
+	return out
+}
+
+// Partition splits s into the elements for which pred holds and the rest,
+// both in their original order.
+func Partition[T any](s []T, pred func(T) bool) (matched, rest []T) {
+	matched, rest = make([]T, 0), make([]T, 0)
+	for _, v := range s {
+		if pred(v) {
+			matched = append(matched, v)
+		} else {
+			rest = append(rest, v)
+		}
+	}
+	return matched, rest
+}
+
+// Zip pairs the elements of a and b by position. The result is as long as
+// the shorter slice.
+func Zip[A, B any](a []A, b []B) []Pair[A, B] {
+	out := make([]Pair[A, B], min(len(a), len(b)))
+	for i := range out {
+		out[i] = Pair[A, B]{First: a[i], Second: b[i]}
+	}
+	return out
+}
+
+// Flatten concatenates the slices of s.
+func Flatten[T any](s [][]T) []T {
+	n := 0
+	for _, inner := range s {
+		n += len(inner)
+	}
+	out := make([]T, 0, n)
+	for _, inner := range s {
+		out = append(out, inner...)
+	}
+	return out
+}
+
+// SortBy returns the elements of s sorted by the key returned by key.
+// Elements with equal keys keep their order.
+func SortBy[T any, K cmp.Ordered](s []T, key func(T) K) []T {
+	out := slices.Clone(s)
+	if out == nil {
+		out = []T{}
+	}
+	slices.SortStableFunc(out, func(a, b T) int {
+		return cmp.Compare(key(a), key(b))
+	})
+	return out
+}
+
+// MinBy returns the first element of s with the smallest key, or false when
+// s is empty.
+func MinBy[T any, K cmp.Ordered](s []T, key func(T) K) (T, bool) {
+	return extremeBy(s, key, -1)
+}
+
+// MaxBy returns the first element of s with the largest key, or false when
+// s is empty.
+func MaxBy[T any, K cmp.Ordered](s []T, key func(T) K) (T, bool) {
+	return extremeBy(s, key, 1)
+}
+
+// extremeBy returns the first element whose key compares to all others
+// as sign.
+func extremeBy[T any, K cmp.Ordered](s []T, key func(T) K, sign int) (T, bool) {
+	if len(s) == 0 {
+		var zero T
+		return zero, false
+	}
+	best, bestKey := s[0], key(s[0])
+	for _, v := range s[1:] {
+		if k := key(v); cmp.Compare(k, bestKey) == sign {
+			best, bestKey = v, k
+		}
+	}
+	return best, true
+}
@@ -0,0 +1,249 @@
+package slicer
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/godev90/validator/faults"
+)
+
+type (
+	// ParamError describes a single rejected query parameter. Field is the
+	// field the parameter refers to, if any.
+	ParamError struct {
+		Parameter string `json:"parameter"`
+		Field     string `json:"field,omitempty"`
+		Reason    string `json:"reason"`
+	}
+
+	// ParamErrors is returned by ParseOptsStrict and lists every rejected
+	// parameter, ordered by parameter name.
+	ParamErrors []ParamError
+)
+
+// looksLikeDate matches values meant as dates, which must then parse.
+var looksLikeDate = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}`)
+
+func (e ParamError) Error() string {
+	if e.Field != "" {
+		return fmt.Sprintf("slicer: invalid parameter %q (field %q): %s", e.Parameter, e.Field, e.Reason)
+	}
+	return fmt.Sprintf("slicer: invalid parameter %q: %s", e.Parameter, e.Reason)
+}
+
+func (e ParamErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Code returns the HTTP status matching the error, 400 Bad Request.
+func (e ParamErrors) Code() faults.ErrCode {
+	return http.StatusBadRequest
+}
+
+// Faults converts the errors into validator faults keyed by parameter name,
+// ready to be rendered in a 400 response.
+func (e ParamErrors) Faults() faults.Errors {
+	reasons := map[string][]string{}
+	for _, err := range e {
+		reason := err.Reason
+		if err.Field != "" && err.Field != err.Parameter {
+			reason = err.Field + ": " + reason
+		}
+		reasons[err.Parameter] = append(reasons[err.Parameter], reason)
+	}
+
+	errs := faults.Errors{}
+	for param, list := range reasons {
+		errs[param] = faults.New(errors.New(strings.Join(list, "; ")), &faults.ErrAttr{
+			Code: http.StatusBadRequest,
+		})
+	}
+	return errs
+}
+
+// ParseOptsStrict parses values like ParseOpts but reports input that
+// ParseOpts would silently drop: non-numeric or non-positive page and limit,
+// fields missing from allowed (in sort, search, search_and, select, group,
+// filters, comparisons and the filter expression), unsupported comparison
+// operators, unparsable dates, wrong value counts and malformed filter
+// expressions. A nil allowed map skips the field checks. The returned error
+// is a ParamErrors; the options are returned either way.
+func ParseOptsStrict(values url.Values, allowed map[string]string) (QueryOptions, error) {
+	return defaultParser().ParseStrict(values, allowed)
+}
+
+// ParseStrict is ParseOptsStrict using the parser's settings. Limits above
+// MaxLimit are reported rather than clamped.
+func (p *Parser) ParseStrict(values url.Values, allowed map[string]string) (QueryOptions, error) {
+	var (
+		opts  = p.Parse(values)
+		names = p.params()
+		sep   = p.separator()
+		errs  ParamErrors
+	)
+
+	reject := func(param, field, reason string) {
+		errs = append(errs, ParamError{Parameter: param, Field: field, Reason: reason})
+	}
+	checkField := func(param, field string) bool {
+		if allowed == nil {
+			return true
+		}
+		if _, ok := allowed[field]; !ok {
+			reject(param, field, "unknown field")
+			return false
+		}
+		return true
+	}
+	checkFields := func(param string) {
+		if raw := values.Get(param); raw != "" {
+			for _, field := range strings.Split(raw, sep) {
+				checkField(param, strings.TrimPrefix(field, "-"))
+			}
+		}
+	}
+
+	for _, param := range []string{names.Page, names.Limit} {
+		if raw, ok := values[param]; ok {
+			n, err := strconv.Atoi(raw[0])
+			switch {
+			case err != nil || n <= 0:
+				reject(param, "", "must be a positive integer")
+			case param == names.Limit && p.MaxLimit > 0 && n > p.MaxLimit:
+				reject(param, "", fmt.Sprintf("must not exceed %d", p.MaxLimit))
+			}
+		}
+	}
+
+	checkFields(names.Sort)
+	checkFields(names.Search)
+	checkFields(names.Select)
+	checkFields(names.Group)
+	if values.Get(names.Search) != "" && values.Get(names.Keyword) == "" {
+		reject(names.Keyword, "", "required when "+names.Search+" is set")
+	}
+
+	for key, val := range values {
+		if names.reserved(key) {
+			continue
+		}
+
+		if field, ok := names.searchAndField(key); ok {
+			checkField(key, field)
+			continue
+		}
+
+		if matches := comparisonPattern.FindStringSubmatch(key); len(matches) == 3 {
+			op := ComparisonOp(matches[2])
+			if !p.allowsOperator(op) {
+				reject(key, matches[1], fmt.Sprintf("unsupported operator %q", op))
+				continue
+			}
+			if checkField(key, matches[1]) {
+				cmp := ComparisonFilter{Field: matches[1], Op: op, Value: p.normalizeValue(op, val[0])}
+				if reason := checkComparisonValue(cmp, sep); reason != "" {
+					reject(key, matches[1], reason)
+				}
+			}
+			continue
+		}
+
+		checkField(key, key)
+	}
+
+	if filter := values.Get(names.Filter); filter != "" {
+		expr, err := parseFilterExpr(filter, sep)
+		var syntaxErr *SyntaxError
+		switch {
+		case errors.As(err, &syntaxErr):
+			reject(names.Filter, "", fmt.Sprintf("%s at position %d", syntaxErr.Msg, syntaxErr.Pos))
+		case err != nil:
+			reject(names.Filter, "", err.Error())
+		default:
+			p.normalizeExpr(expr)
+			expr.each(func(cmp ComparisonFilter) {
+				if !p.allowsOperator(cmp.Op) {
+					reject(names.Filter, cmp.Field, fmt.Sprintf("unsupported operator %q", cmp.Op))
+					return
+				}
+				if checkField(names.Filter, cmp.Field) {
+					if reason := checkComparisonValue(cmp, sep); reason != "" {
+						reject(names.Filter, cmp.Field, reason)
+					}
+				}
+			})
+		}
+	}
+
+	if len(errs) == 0 {
+		return opts, nil
+	}
+	sort.SliceStable(errs, func(i, j int) bool {
+		if errs[i].Parameter != errs[j].Parameter {
+			return errs[i].Parameter < errs[j].Parameter
+		}
+		return errs[i].Field < errs[j].Field
+	})
+	return opts, errs
+}
+
+// checkComparisonValue validates the value of a comparison for its operator
+// and returns the reason it is rejected, or an empty string.
+func checkComparisonValue(cmp ComparisonFilter, sep string) string {
+	switch cmp.Op {
+	case ISNULL, NOTNULL:
+		if _, ok := nullFlag(cmp.Value); !ok {
+			return "must be a boolean"
+		}
+		return ""
+	case LIKE, ILIKE, PREFIX, SUFFIX:
+		return ""
+	case REGEX:
+		if _, err := regexp.Compile(cmp.Value); err != nil {
+			return "invalid regular expression"
+		}
+		return ""
+	}
+
+	parts := []string{cmp.Value}
+	switch cmp.Op {
+	case BETWEEN:
+		parts = strings.Split(cmp.Value, sep)
+		if len(parts) != 2 {
+			return "between takes exactly two values"
+		}
+	case IN, NIN:
+		parts = strings.Split(cmp.Value, sep)
+		if cmp.Value == "" {
+			return fmt.Sprintf("%s takes at least one value", cmp.Op)
+		}
+	}
+
+	for _, part := range parts {
+		if looksLikeDate.MatchString(part) && !isTime(part) {
+			return fmt.Sprintf("invalid date %q", part)
+		}
+	}
+	return ""
+}
+
+// isTime reports whether value parses with one of timeLayouts.
+func isTime(value string) bool {
+	for _, layout := range timeLayouts {
+		if _, err := time.Parse(layout, value); err == nil {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,160 @@
+package slicer_test
+
+import (
+	"cmp"
+	"reflect"
+	"testing"
+
+	"github.com/godev90/slicer"
+	"github.com/godev90/validator/typedef"
+)
+
+type accessorUser struct {
+	ID    int             `json:"id"`
+	Name  string          `json:"name"`
+	Score typedef.Integer `json:"score"`
+	Team  string
+}
+
+// accessorCalls counts the calls of the registered accessors.
+var accessorCalls int
+
+// registered as slicer-gen would, with counters
+func init() {
+	slicer.RegisterAccessors(slicer.Accessors[accessorUser]{
+		Fields: map[string]func(accessorUser) any{
+			"id":    func(v accessorUser) any { accessorCalls++; return v.ID },
+			"name":  func(v accessorUser) any { accessorCalls++; return v.Name },
+			"score": func(v accessorUser) any { accessorCalls++; return v.Score },
+		},
+		Compare: map[string]func(a, b accessorUser) int{
+			"id":    func(a, b accessorUser) int { accessorCalls++; return cmp.Compare(a.ID, b.ID) },
+			"score": func(a, b accessorUser) int { accessorCalls++; return cmp.Compare(a.Score.Int64(), b.Score.Int64()) },
+		},
+	})
+}
+
+func TestRegisteredAccessors(t *testing.T) {
+	users := []accessorUser{
+		{ID: 1, Name: "Ann", Score: typedef.NewInteger(30), Team: "core"},
+		{ID: 2, Name: "Bob", Score: typedef.NewInteger(10), Team: "web"},
+		{ID: 3, Name: "Cid", Score: typedef.NewInteger(20), Team: "core"},
+		{ID: 4, Name: "Dee", Score: typedef.NewInteger(40), Team: "ops"},
+	}
+	allowed := map[string]string{"id": "id", "name": "name", "score": "score", "team": "team"}
+	opts := slicer.QueryOptions{
+		Page:        1,
+		Limit:       10,
+		Sort:        []slicer.SortField{{Field: "score", Desc: true}},
+		Comparisons: []slicer.ComparisonFilter{{Field: "id", Op: slicer.GT, Value: "1"}},
+		Filters:     map[string]string{"team": "core"},
+		Search:      &slicer.SearchQuery{Fields: []string{"name"}, Keyword: "c"},
+	}
+
+	ids := func(data slicer.PageData) []int {
+		var out []int
+		items := reflect.ValueOf(data.Items)
+		for i := 0; i < items.Len(); i++ {
+			out = append(out, int(reflect.Indirect(items.Index(i)).FieldByName("ID").Int()))
+		}
+		return out
+	}
+
+	accessorCalls = 0
+	data, err := slicer.SlicePage(slicer.NewSlicePaginator(users, allowed), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := ids(data); !reflect.DeepEqual(got, []int{3}) {
+		t.Errorf("Expected [3], got %v", got)
+	}
+	if accessorCalls == 0 {
+		t.Error("Expected the registered accessors to be used")
+	}
+
+	t.Run("Sorting uses Compare", func(t *testing.T) {
+		accessorCalls = 0
+		data, _ := slicer.SlicePage(slicer.NewSlicePaginator(users, allowed), slicer.QueryOptions{
+			Page: 1, Limit: 10, Sort: []slicer.SortField{{Field: "score"}},
+		})
+		if got := ids(data); !reflect.DeepEqual(got, []int{2, 3, 1, 4}) {
+			t.Errorf("Expected [2 3 1 4], got %v", got)
+		}
+		if accessorCalls == 0 {
+			t.Error("Expected the registered compare function to be used")
+		}
+	})
+
+	t.Run("Pointer slices share the accessors", func(t *testing.T) {
+		ptrs := make([]*accessorUser, len(users))
+		for i := range users {
+			ptrs[i] = &users[i]
+		}
+		accessorCalls = 0
+		data, _ := slicer.SlicePage(slicer.NewSlicePaginator(ptrs, allowed), opts)
+		if got := ids(data); !reflect.DeepEqual(got, []int{3}) || accessorCalls == 0 {
+			t.Errorf("Expected [3] through the accessors, got %v (%d calls)", got, accessorCalls)
+		}
+	})
+
+	t.Run("Results match reflection", func(t *testing.T) {
+		type plainUser struct {
+			ID    int             `json:"id"`
+			Name  string          `json:"name"`
+			Score typedef.Integer `json:"score"`
+			Team  string
+		}
+		plain := make([]plainUser, len(users))
+		for i, u := range users {
+			plain[i] = plainUser(u)
+		}
+		for _, o := range []slicer.QueryOptions{opts, {Page: 1, Limit: 10, Sort: []slicer.SortField{{Field: "name", Desc: true}}}} {
+			want, _ := slicer.SlicePage(slicer.NewSlicePaginator(plain, allowed), o)
+			got, _ := slicer.SlicePage(slicer.NewSlicePaginator(users, allowed), o)
+			if !reflect.DeepEqual(ids(got), ids(want)) || got.Total != want.Total {
+				t.Errorf("Expected %v, got %v", ids(want), ids(got))
+			}
+		}
+	})
+}
+
+// rankedUser has a rank only its registered accessors know about.
+type rankedUser struct {
+	ID   int `json:"id"`
+	Wins int `json:"wins"`
+}
+
+func init() {
+	slicer.RegisterAccessors(slicer.Accessors[rankedUser]{
+		Fields: map[string]func(rankedUser) any{
+			"rank": func(v rankedUser) any { return 100 - v.Wins },
+		},
+	})
+}
+
+func TestCursorUsesAccessors(t *testing.T) {
+	users := make([]rankedUser, 7)
+	for i := range users {
+		users[i] = rankedUser{ID: i + 1, Wins: (i * 3) % 7}
+	}
+	p := slicer.NewSlicePaginator(users, map[string]string{"id": "id", "rank": "rank"})
+
+	var got []int
+	opts := slicer.QueryOptions{Page: 1, Limit: 3, Sort: []slicer.SortField{{Field: "rank"}}, Cursor: &slicer.CursorQuery{}}
+	for range 5 {
+		data, err := slicer.SlicePage(p, opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, u := range data.Items.([]rankedUser) {
+			got = append(got, u.Wins)
+		}
+		if data.NextCursor == "" {
+			break
+		}
+		opts.Cursor = &slicer.CursorQuery{After: data.NextCursor}
+	}
+	if want := []int{6, 5, 4, 3, 2, 1, 0}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected wins %v by rank, got %v", want, got)
+	}
+}
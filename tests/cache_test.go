@@ -0,0 +1,101 @@
+package slicer_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/godev90/slicer"
+)
+
+func TestLRUCache(t *testing.T) {
+	t.Run("Get returns stored values", func(t *testing.T) {
+		c := slicer.NewLRUCache(4)
+		c.Set("users", "a", 1, 0)
+		c.Set("orders", "a", 2, 0)
+
+		if v, ok := c.Get("users", "a"); !ok || v != 1 {
+			t.Errorf("Expected 1, got %v (%v)", v, ok)
+		}
+		if v, ok := c.Get("orders", "a"); !ok || v != 2 {
+			t.Errorf("Expected 2, got %v (%v)", v, ok)
+		}
+		if _, ok := c.Get("users", "b"); ok {
+			t.Errorf("Expected a miss for an unknown key")
+		}
+	})
+
+	t.Run("Least recently used entries are evicted", func(t *testing.T) {
+		c := slicer.NewLRUCache(2)
+		c.Set("t", "a", 1, 0)
+		c.Set("t", "b", 2, 0)
+		c.Get("t", "a")
+		c.Set("t", "c", 3, 0)
+
+		if _, ok := c.Get("t", "b"); ok {
+			t.Errorf("Expected b to be evicted")
+		}
+		if _, ok := c.Get("t", "a"); !ok {
+			t.Errorf("Expected a to survive")
+		}
+		if c.Len() != 2 {
+			t.Errorf("Expected 2 entries, got %d", c.Len())
+		}
+	})
+
+	t.Run("Entries expire after their TTL", func(t *testing.T) {
+		c := slicer.NewLRUCache(4)
+		c.Set("t", "short", 1, 10*time.Millisecond)
+		c.Set("t", "long", 2, time.Hour)
+		time.Sleep(20 * time.Millisecond)
+
+		if _, ok := c.Get("t", "short"); ok {
+			t.Errorf("Expected short to expire")
+		}
+		if _, ok := c.Get("t", "long"); !ok {
+			t.Errorf("Expected long to be live")
+		}
+	})
+
+	t.Run("Invalidate drops only the given table", func(t *testing.T) {
+		c := slicer.NewLRUCache(8)
+		c.Set("users", "a", 1, 0)
+		c.Set("users", "b", 2, 0)
+		c.Set("orders", "a", 3, 0)
+		c.Invalidate("users")
+
+		if _, ok := c.Get("users", "a"); ok {
+			t.Errorf("Expected users entries to be dropped")
+		}
+		if _, ok := c.Get("orders", "a"); !ok {
+			t.Errorf("Expected orders entries to survive")
+		}
+		if c.Len() != 1 {
+			t.Errorf("Expected 1 entry, got %d", c.Len())
+		}
+	})
+
+	t.Run("Concurrent use", func(t *testing.T) {
+		c := slicer.NewLRUCache(16)
+		var wg sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				for j := 0; j < 200; j++ {
+					key := fmt.Sprint(j % 32)
+					c.Set("t", key, j, time.Minute)
+					c.Get("t", key)
+					if j%50 == 0 {
+						c.Invalidate("t")
+					}
+				}
+			}(i)
+		}
+		wg.Wait()
+		if c.Len() > 16 {
+			t.Errorf("Expected at most 16 entries, got %d", c.Len())
+		}
+	})
+}
@@ -0,0 +1,120 @@
+package slicer_test
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/godev90/slicer"
+)
+
+func TestQueryOptionsHash(t *testing.T) {
+	t.Run("Equivalent queries share a hash", func(t *testing.T) {
+		tests := []struct {
+			name string
+			a, b url.Values
+		}{
+			{
+				"defaults",
+				url.Values{},
+				url.Values{"page": {"1"}, "limit": {"10"}},
+			},
+			{
+				"duplicate sort entries",
+				url.Values{"sort": {"-created_at,id"}},
+				url.Values{"sort": {"-created_at,id,created_at"}},
+			},
+			{
+				"search field order",
+				url.Values{"search": {"name,email"}, "keyword": {"jo"}},
+				url.Values{"search": {"email,name,email"}, "keyword": {"jo"}},
+			},
+			{
+				"search_and spelling",
+				url.Values{"searchAnd.city": {"Paris"}},
+				url.Values{"search_and.city": {"Paris"}},
+			},
+			{
+				"in list order",
+				url.Values{"id[in]": {"3,1,2"}},
+				url.Values{"id[in]": {"1,2,3,1"}},
+			},
+			{
+				"null flags",
+				url.Values{"deleted_at[isnull]": {"false"}},
+				url.Values{"deleted_at[notnull]": {""}},
+			},
+			{
+				"filter expression shape",
+				url.Values{"filter": {"and(eq(a,1),and(eq(b,2),not(not(eq(c,3)))))"}},
+				url.Values{"filter": {"and(eq(c,3),eq(b,2),eq(a,1),eq(a,1))"}},
+			},
+			{
+				"select order",
+				url.Values{"select": {"id,name"}},
+				url.Values{"select": {"name,id"}},
+			},
+		}
+		for _, tt := range tests {
+			a, b := slicer.ParseOpts(tt.a), slicer.ParseOpts(tt.b)
+			if a.Hash() != b.Hash() {
+				t.Errorf("%s: expected equal hashes for\n %s\n %s", tt.name, a.Canonical().Encode(), b.Canonical().Encode())
+			}
+		}
+	})
+
+	t.Run("Filters map order is irrelevant", func(t *testing.T) {
+		keys := []string{"a", "b", "c", "d", "e", "f", "g", "h"}
+		a := slicer.QueryOptions{Page: 1, Limit: 10, Filters: map[string]string{}}
+		b := slicer.QueryOptions{Page: 1, Limit: 10, Filters: map[string]string{}}
+		for i := range keys {
+			a.Filters[keys[i]] = "x"
+			b.Filters[keys[len(keys)-1-i]] = "x"
+		}
+		for i := 0; i < 10; i++ {
+			if a.Hash() != b.Hash() {
+				t.Fatalf("Expected equal hashes")
+			}
+		}
+	})
+
+	t.Run("Different queries get different hashes", func(t *testing.T) {
+		queries := []url.Values{
+			{},
+			{"page": {"2"}},
+			{"limit": {"20"}},
+			{"sort": {"id"}},
+			{"sort": {"-id"}},
+			{"sort": {"a,b"}},
+			{"sort": {"b,a"}},
+			{"status": {"open"}},
+			{"status": {"closed"}},
+			{"id[in]": {"1,2"}},
+			{"id[nin]": {"1,2"}},
+			{"filter": {"or(eq(a,1),eq(b,2))"}},
+			{"filter": {"and(eq(a,1),eq(b,2))"}},
+			{"cursor": {""}},
+		}
+		seen := map[string]int{}
+		for i, values := range queries {
+			hash := slicer.ParseOpts(values).Hash()
+			if j, ok := seen[hash]; ok {
+				t.Errorf("Queries %v and %v share a hash", queries[j], values)
+			}
+			seen[hash] = i
+		}
+
+		withSkip := slicer.ParseOpts(url.Values{})
+		withSkip.Offset = 5
+		if withSkip.Hash() == slicer.ParseOpts(url.Values{}).Hash() {
+			t.Errorf("Explicit offsets must change the hash")
+		}
+	})
+
+	t.Run("Canonical does not modify the receiver", func(t *testing.T) {
+		opts := slicer.ParseOpts(url.Values{"sort": {"b,a,b"}, "id[in]": {"3,1"}, "select": {"z,y"}})
+		_ = opts.Canonical()
+		if len(opts.Sort) != 3 || opts.Comparisons[0].Value != "3,1" || opts.Select[0] != "z" {
+			t.Errorf("Receiver was modified: %+v", opts)
+		}
+	})
+}
@@ -0,0 +1,137 @@
+package slicer_test
+
+import (
+	"database/sql"
+	"net/url"
+	"testing"
+
+	"github.com/godev90/slicer"
+)
+
+func TestParseOptsExtendedOperators(t *testing.T) {
+	values := url.Values{
+		"status[ne]":         {"archived"},
+		"id[in]":             {"1,2,3"},
+		"id[nin]":            {"4"},
+		"age[between]":       {"18,65"},
+		"deleted_at[isnull]": {"true"},
+		"owner[notnull]":     {""},
+		"age[near]":          {"5"},
+	}
+
+	opts := slicer.ParseOpts(values)
+
+	got := map[slicer.ComparisonOp]string{}
+	for _, c := range opts.Comparisons {
+		got[c.Op] = c.Field + "=" + c.Value
+	}
+
+	want := map[slicer.ComparisonOp]string{
+		slicer.NE:      "status=archived",
+		slicer.IN:      "id=1,2,3",
+		slicer.NIN:     "id=4",
+		slicer.BETWEEN: "age=18,65",
+		slicer.ISNULL:  "deleted_at=true",
+		slicer.NOTNULL: "owner=",
+	}
+	for op, expected := range want {
+		if got[op] != expected {
+			t.Errorf("Expected %s comparison %q, got %q", op, expected, got[op])
+		}
+	}
+
+	if _, ok := opts.Filters["age[near]"]; !ok {
+		t.Error("Unknown operators should remain plain filters")
+	}
+}
+
+func TestSlicePageExtendedOperators(t *testing.T) {
+	type Account struct {
+		ID        int            `json:"id"`
+		Status    string         `json:"status"`
+		Age       int            `json:"age"`
+		Owner     *string        `json:"owner"`
+		DeletedAt sql.NullString `json:"deleted_at"`
+	}
+
+	alice, bob := "alice", "bob"
+	accounts := []Account{
+		{ID: 1, Status: "open", Age: 17, Owner: &alice},
+		{ID: 2, Status: "archived", Age: 30, DeletedAt: sql.NullString{String: "2024-01-01", Valid: true}},
+		{ID: 3, Status: "pending", Age: 65, Owner: &bob},
+		{ID: 4, Status: "open", Age: 70},
+	}
+	fields := slicer.DefaultFilterByJson[Account]()
+
+	run := func(t *testing.T, cmp slicer.ComparisonFilter) []int {
+		t.Helper()
+		paginator := slicer.NewSlicePaginator(accounts, fields)
+		data, err := slicer.SlicePage(paginator, slicer.QueryOptions{
+			Page:        1,
+			Limit:       10,
+			Comparisons: []slicer.ComparisonFilter{cmp},
+		})
+		if err != nil {
+			t.Fatalf("SlicePage failed: %v", err)
+		}
+		var ids []int
+		for _, a := range data.Items.([]Account) {
+			ids = append(ids, a.ID)
+		}
+		return ids
+	}
+
+	tests := []struct {
+		name string
+		cmp  slicer.ComparisonFilter
+		want []int
+	}{
+		{"ne", slicer.ComparisonFilter{Field: "status", Op: slicer.NE, Value: "archived"}, []int{1, 3, 4}},
+		{"ne on nullable matches nulls", slicer.ComparisonFilter{Field: "owner", Op: slicer.NE, Value: "alice"}, []int{2, 3, 4}},
+		{"ne skips types eq cannot compare", slicer.ComparisonFilter{Field: "deleted_at", Op: slicer.NE, Value: "2024-02-02"}, []int{1, 3, 4}},
+		{"in", slicer.ComparisonFilter{Field: "id", Op: slicer.IN, Value: "1,3,9"}, []int{1, 3}},
+		{"nin", slicer.ComparisonFilter{Field: "status", Op: slicer.NIN, Value: "open,pending"}, []int{2}},
+		{"between is inclusive", slicer.ComparisonFilter{Field: "age", Op: slicer.BETWEEN, Value: "18,65"}, []int{2, 3}},
+		{"isnull pointer", slicer.ComparisonFilter{Field: "owner", Op: slicer.ISNULL, Value: "true"}, []int{2, 4}},
+		{"isnull false", slicer.ComparisonFilter{Field: "owner", Op: slicer.ISNULL, Value: "false"}, []int{1, 3}},
+		{"isnull needs a boolean", slicer.ComparisonFilter{Field: "owner", Op: slicer.ISNULL, Value: "maybe"}, []int{1, 2, 3, 4}},
+		{"notnull valuer", slicer.ComparisonFilter{Field: "deleted_at", Op: slicer.NOTNULL}, []int{2}},
+		{"eq through pointer", slicer.ComparisonFilter{Field: "owner", Op: slicer.EQ, Value: "bob"}, []int{3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := run(t, tt.cmp)
+			if len(got) != len(tt.want) {
+				t.Fatalf("Expected %v, got %v", tt.want, got)
+			}
+			for i := range tt.want {
+				if got[i] != tt.want[i] {
+					t.Fatalf("Expected %v, got %v", tt.want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestExtendedOperatorsProtoRoundTrip(t *testing.T) {
+	opts := slicer.QueryOptions{
+		Page:  1,
+		Limit: 10,
+		Comparisons: []slicer.ComparisonFilter{
+			{Field: "id", Op: slicer.IN, Value: "1,2"},
+			{Field: "age", Op: slicer.BETWEEN, Value: "18,65"},
+			{Field: "deleted_at", Op: slicer.ISNULL, Value: "true"},
+		},
+	}
+
+	back := slicer.QueryFromProto(opts.ToProto())
+	if len(back.Comparisons) != len(opts.Comparisons) {
+		t.Fatalf("Expected %d comparisons, got %d", len(opts.Comparisons), len(back.Comparisons))
+	}
+	for i, c := range opts.Comparisons {
+		if back.Comparisons[i] != c {
+			t.Errorf("Comparison %d changed: %+v != %+v", i, back.Comparisons[i], c)
+		}
+	}
+}
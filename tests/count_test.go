@@ -0,0 +1,91 @@
+package slicer_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/godev90/slicer"
+)
+
+func TestCountStrategy(t *testing.T) {
+	t.Run("TotalString", func(t *testing.T) {
+		tests := []struct {
+			page slicer.PageData
+			want string
+		}{
+			{slicer.PageData{Total: 42}, "42"},
+			{slicer.PageData{Total: 1000, TotalCapped: true}, "1000+"},
+			{slicer.PageData{Total: -1}, ""},
+		}
+		for _, tt := range tests {
+			if got := tt.page.TotalString(); got != tt.want {
+				t.Errorf("Expected %q, got %q", tt.want, got)
+			}
+		}
+	})
+
+	t.Run("PlanRows", func(t *testing.T) {
+		explain := []byte(`[{"Plan": {"Node Type": "Seq Scan", "Plan Rows": 12345.0, "Plan Width": 8}}]`)
+		rows, err := slicer.PlanRows(explain)
+		if err != nil || rows != 12345 {
+			t.Errorf("Expected 12345, got %d (%v)", rows, err)
+		}
+
+		if _, err := slicer.PlanRows([]byte(`[]`)); !errors.Is(err, slicer.ErrNoPlanRows) {
+			t.Errorf("Expected ErrNoPlanRows, got %v", err)
+		}
+		if _, err := slicer.PlanRows([]byte(`not json`)); err == nil {
+			t.Errorf("Expected an error for invalid output")
+		}
+	})
+
+	t.Run("Strategy is part of the hash", func(t *testing.T) {
+		exact := slicer.QueryOptions{Page: 1, Limit: 10, Count: slicer.CountExact}
+		none := slicer.QueryOptions{Page: 1, Limit: 10, Count: slicer.CountNone}
+		if exact.Hash() == none.Hash() {
+			t.Errorf("Expected different hashes for different count strategies")
+		}
+	})
+
+	t.Run("SlicePage reports an exact total and HasMore", func(t *testing.T) {
+		type item struct {
+			ID int `json:"id"`
+		}
+		items := []item{{ID: 1}, {ID: 2}, {ID: 3}}
+		p := slicer.NewSlicePaginator(items, map[string]string{"id": "id"})
+
+		page, err := slicer.SlicePage(p, slicer.QueryOptions{Page: 1, Limit: 2})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if page.CountStrategy != slicer.CountExact || !page.HasMore {
+			t.Errorf("Unexpected first page: %+v", page)
+		}
+
+		page, _ = slicer.SlicePage(p, slicer.QueryOptions{Page: 2, Limit: 2, Offset: 2})
+		if page.HasMore {
+			t.Errorf("Expected no more items after the last page")
+		}
+	})
+
+	t.Run("Proto round trip", func(t *testing.T) {
+		opts := slicer.QueryOptions{Page: 1, Limit: 10, Count: slicer.CountCapped}
+		if back := slicer.QueryFromProto(opts.ToProto()); back.Count != slicer.CountCapped {
+			t.Errorf("Expected the count strategy to survive, got %q", back.Count)
+		}
+
+		data := slicer.PageData{Items: []int{1}, Total: 1000, Page: 1, Limit: 10, CountStrategy: slicer.CountCapped, TotalCapped: true, HasMore: true}
+		pb, err := data.ToProtoBuf()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var dest []int
+		back, err := slicer.PageFromProtoBuf(pb, &dest)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if back.CountStrategy != slicer.CountCapped || !back.TotalCapped || !back.HasMore {
+			t.Errorf("Unexpected page: %+v", back)
+		}
+	})
+}
@@ -0,0 +1,289 @@
+package slicer_test
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+
+	"github.com/godev90/slicer"
+)
+
+// cursor pages need a signing key
+func init() {
+	slicer.SetCursorSecret([]byte("cursor test secret"))
+}
+
+func TestParseOptsCursor(t *testing.T) {
+	t.Run("Empty cursor starts cursor mode", func(t *testing.T) {
+		opts := slicer.ParseOpts(url.Values{"cursor": {""}, "sort": {"name"}})
+
+		if opts.Cursor == nil {
+			t.Fatal("Expected cursor mode")
+		}
+		if opts.Cursor.After != "" || opts.Cursor.Before != "" {
+			t.Errorf("Expected empty cursor, got %+v", opts.Cursor)
+		}
+		if _, ok := opts.Filters["cursor"]; ok {
+			t.Error("cursor must not be treated as a filter")
+		}
+	})
+
+	t.Run("After and before tokens", func(t *testing.T) {
+		opts := slicer.ParseOpts(url.Values{"cursor": {"abc"}, "before": {"def"}})
+
+		if opts.Cursor == nil || opts.Cursor.After != "abc" || opts.Cursor.Before != "def" {
+			t.Errorf("Unexpected cursor: %+v", opts.Cursor)
+		}
+		if len(opts.Filters) != 0 {
+			t.Errorf("Expected no filters, got %v", opts.Filters)
+		}
+	})
+
+	t.Run("No cursor parameters keeps offset mode", func(t *testing.T) {
+		opts := slicer.ParseOpts(url.Values{"page": {"2"}})
+
+		if opts.Cursor != nil {
+			t.Errorf("Expected offset mode, got %+v", opts.Cursor)
+		}
+	})
+}
+
+func TestCursorProtoRoundTrip(t *testing.T) {
+	opts := slicer.QueryOptions{
+		Page:   1,
+		Limit:  20,
+		Cursor: &slicer.CursorQuery{After: "next-token"},
+	}
+
+	back := slicer.QueryFromProto(opts.ToProto())
+	if back.Cursor == nil || back.Cursor.After != "next-token" || back.Cursor.Before != "" {
+		t.Errorf("Cursor lost in round trip: %+v", back.Cursor)
+	}
+
+	if slicer.QueryFromProto(slicer.QueryOptions{Page: 1, Limit: 10}.ToProto()).Cursor != nil {
+		t.Error("Offset mode must not gain a cursor")
+	}
+
+	data := slicer.PageData{
+		Items:      []int{1, 2},
+		Total:      2,
+		Page:       1,
+		Limit:      2,
+		NextCursor: "n",
+		PrevCursor: "p",
+	}
+
+	pb, err := data.ToProto()
+	if err != nil {
+		t.Fatalf("ToProto failed: %v", err)
+	}
+	var items []int
+	page, err := slicer.PageFromProto(pb, &items)
+	if err != nil {
+		t.Fatalf("PageFromProto failed: %v", err)
+	}
+	if page.NextCursor != "n" || page.PrevCursor != "p" {
+		t.Errorf("Cursors lost in PageData round trip: %+v", page)
+	}
+
+	buf, err := data.ToProtoBuf()
+	if err != nil {
+		t.Fatalf("ToProtoBuf failed: %v", err)
+	}
+	page, err = slicer.PageFromProtoBuf(buf, &items)
+	if err != nil {
+		t.Fatalf("PageFromProtoBuf failed: %v", err)
+	}
+	if page.NextCursor != "n" || page.PrevCursor != "p" {
+		t.Errorf("Cursors lost in PageDataBuf round trip: %+v", page)
+	}
+}
+
+func TestSlicePageCursor(t *testing.T) {
+	type Task struct {
+		ID       int    `json:"id"`
+		Priority int    `json:"priority"`
+		Title    string `json:"title"`
+	}
+
+	tasks := []Task{
+		{ID: 1, Priority: 2, Title: "a"},
+		{ID: 2, Priority: 1, Title: "b"},
+		{ID: 3, Priority: 2, Title: "c"},
+		{ID: 4, Priority: 3, Title: "d"},
+		{ID: 5, Priority: 1, Title: "e"},
+		{ID: 6, Priority: 2, Title: "f"},
+		{ID: 7, Priority: 3, Title: "g"},
+	}
+	fields := map[string]string{"id": "id", "priority": "priority", "title": "title"}
+
+	ids := func(data slicer.PageData) []int {
+		var out []int
+		for _, task := range data.Items.([]Task) {
+			out = append(out, task.ID)
+		}
+		return out
+	}
+
+	walk := func(t *testing.T, paginator *slicer.SlicePaginator[Task], sort []slicer.SortField) []int {
+		t.Helper()
+		var (
+			all  []int
+			opts = slicer.QueryOptions{Page: 1, Limit: 3, Sort: sort, Cursor: &slicer.CursorQuery{}}
+		)
+		for i := 0; i < 10; i++ {
+			data, err := slicer.SlicePage(paginator, opts)
+			if err != nil {
+				t.Fatalf("SlicePage failed: %v", err)
+			}
+			all = append(all, ids(data)...)
+			if data.NextCursor == "" {
+				return all
+			}
+			opts.Cursor = &slicer.CursorQuery{After: data.NextCursor}
+		}
+		t.Fatal("cursor walk did not terminate")
+		return nil
+	}
+
+	t.Run("Forward walk with unique tiebreaker", func(t *testing.T) {
+		paginator := slicer.NewSlicePaginator(tasks, fields)
+		got := walk(t, paginator, []slicer.SortField{{Field: "priority", Desc: true}})
+		want := []int{7, 4, 6, 3, 1, 5, 2}
+
+		if len(got) != len(want) {
+			t.Fatalf("Expected %v, got %v", want, got)
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Fatalf("Expected %v, got %v", want, got)
+			}
+		}
+	})
+
+	t.Run("Forward walk without tiebreaker keeps duplicates", func(t *testing.T) {
+		noID := map[string]string{"priority": "priority", "title": "title"}
+		paginator := slicer.NewSlicePaginator(tasks, noID)
+		got := walk(t, paginator, []slicer.SortField{{Field: "priority"}})
+
+		if len(got) != len(tasks) {
+			t.Fatalf("Expected every item exactly once, got %v", got)
+		}
+		seen := map[int]bool{}
+		for _, id := range got {
+			if seen[id] {
+				t.Fatalf("Item %d returned twice: %v", id, got)
+			}
+			seen[id] = true
+		}
+	})
+
+	t.Run("Backward walk returns previous page", func(t *testing.T) {
+		paginator := slicer.NewSlicePaginator(tasks, fields)
+		opts := slicer.QueryOptions{Page: 1, Limit: 3, Sort: []slicer.SortField{{Field: "title"}}, Cursor: &slicer.CursorQuery{}}
+
+		first, _ := slicer.SlicePage(paginator, opts)
+		if first.PrevCursor != "" {
+			t.Error("First page must not have a previous cursor")
+		}
+
+		opts.Cursor = &slicer.CursorQuery{After: first.NextCursor}
+		second, _ := slicer.SlicePage(paginator, opts)
+		if got := ids(second); len(got) != 3 || got[0] != 4 {
+			t.Fatalf("Unexpected second page: %v", got)
+		}
+
+		opts.Cursor = &slicer.CursorQuery{Before: second.PrevCursor}
+		back, _ := slicer.SlicePage(paginator, opts)
+		if got := ids(back); len(got) != 3 || got[0] != 1 || got[2] != 3 {
+			t.Errorf("Expected first page again, got %v", got)
+		}
+		if back.PrevCursor != "" || back.NextCursor == "" {
+			t.Errorf("Unexpected cursors on first page: %+v", back)
+		}
+	})
+
+	t.Run("Cursor resumes after filtering", func(t *testing.T) {
+		paginator := slicer.NewSlicePaginator(tasks, fields)
+		opts := slicer.QueryOptions{
+			Page:        1,
+			Limit:       1,
+			Comparisons: []slicer.ComparisonFilter{{Field: "priority", Op: slicer.EQ, Value: "2"}},
+			Cursor:      &slicer.CursorQuery{},
+		}
+
+		first, _ := slicer.SlicePage(paginator, opts)
+		opts.Cursor = &slicer.CursorQuery{After: first.NextCursor}
+		second, _ := slicer.SlicePage(paginator, opts)
+
+		if first.Total != 3 || ids(first)[0] != 1 || ids(second)[0] != 3 {
+			t.Errorf("Unexpected pages: %v %v", ids(first), ids(second))
+		}
+	})
+
+	t.Run("Tampered or foreign tokens are rejected", func(t *testing.T) {
+		paginator := slicer.NewSlicePaginator(tasks, fields)
+		opts := slicer.QueryOptions{Page: 1, Limit: 2, Cursor: &slicer.CursorQuery{}}
+		first, _ := slicer.SlicePage(paginator, opts)
+
+		opts.Cursor = &slicer.CursorQuery{After: first.NextCursor + "x"}
+		if _, err := slicer.SlicePage(paginator, opts); err != slicer.ErrInvalidCursor {
+			t.Errorf("Expected ErrInvalidCursor, got %v", err)
+		}
+
+		other := slicer.NewSlicePaginator(tasks, fields).Configure(slicer.Config{CursorSecret: []byte("other")})
+		opts.Cursor = &slicer.CursorQuery{After: first.NextCursor}
+		data, err := slicer.SlicePage(other, opts)
+		if err != slicer.ErrInvalidCursor || data.LastError == nil {
+			t.Errorf("Expected ErrInvalidCursor with LastError, got %v", err)
+		}
+	})
+
+	t.Run("Cursor pages need a secret", func(t *testing.T) {
+		slicer.SetCursorSecret(nil)
+		defer slicer.SetCursorSecret([]byte("cursor test secret"))
+
+		opts := slicer.QueryOptions{Page: 1, Limit: 2, Cursor: &slicer.CursorQuery{}}
+		data, err := slicer.SlicePage(slicer.NewSlicePaginator(tasks, fields), opts)
+		if !errors.Is(err, slicer.ErrNoCursorSecret) || data.LastError == nil {
+			t.Errorf("Expected ErrNoCursorSecret with LastError, got %v", err)
+		}
+
+		configured := slicer.NewSlicePaginator(tasks, fields).Configure(slicer.Config{CursorSecret: []byte("own")})
+		if _, err := slicer.SlicePage(configured, opts); err != nil {
+			t.Errorf("Expected Config.CursorSecret to suffice, got %v", err)
+		}
+	})
+
+	t.Run("Nullable sort fields are rejected", func(t *testing.T) {
+		type Job struct {
+			ID  int  `json:"id"`
+			Due *int `json:"due"`
+		}
+		due := 3
+		jobs := []Job{{ID: 1, Due: &due}, {ID: 2}}
+		paginator := slicer.NewSlicePaginator(jobs, map[string]string{"id": "id", "due": "due"})
+
+		opts := slicer.QueryOptions{Page: 1, Limit: 1, Sort: []slicer.SortField{{Field: "due"}}, Cursor: &slicer.CursorQuery{}}
+		data, err := slicer.SlicePage(paginator, opts)
+		if !errors.Is(err, slicer.ErrNullableCursorField) || data.LastError == nil {
+			t.Errorf("Expected ErrNullableCursorField with LastError, got %v", err)
+		}
+
+		opts.Cursor = nil
+		if _, err := slicer.SlicePage(paginator, opts); err != nil {
+			t.Errorf("Expected offset pages to sort by nullable fields, got %v", err)
+		}
+	})
+
+	t.Run("Tokens are stable", func(t *testing.T) {
+		paginator := slicer.NewSlicePaginator(tasks, fields)
+		opts := slicer.QueryOptions{Page: 1, Limit: 2, Cursor: &slicer.CursorQuery{}}
+		a, _ := slicer.SlicePage(paginator, opts)
+		b, _ := slicer.SlicePage(paginator, opts)
+
+		if a.NextCursor == "" || a.NextCursor != b.NextCursor {
+			t.Errorf("Expected identical tokens, got %q and %q", a.NextCursor, b.NextCursor)
+		}
+	})
+}
@@ -0,0 +1,218 @@
+package slicer_test
+
+import (
+	"math/rand"
+	"net/url"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/godev90/slicer"
+)
+
+func TestQueryOptionsEncode(t *testing.T) {
+	t.Run("Canonical query string", func(t *testing.T) {
+		opts := slicer.ParseOpts(url.Values{
+			"page":           {"2"},
+			"limit":          {"20"},
+			"sort":           {"-created_at,id"},
+			"search":         {"name,email"},
+			"keyword":        {"jo doe"},
+			"searchAnd.city": {"Paris"},
+			"age[between]":   {"18,65"},
+			"status":         {"open"},
+			"filter":         {"or(eq(a,1),isnull(b))"},
+		})
+
+		want := "age%5Bbetween%5D=18%2C65&filter=or%28eq%28a%2C1%29%2Cisnull%28b%29%29&keyword=jo+doe&limit=20&page=2" +
+			"&search=name%2Cemail&search_and.city=Paris&sort=-created_at%2Cid&status=open"
+		if got := opts.Encode(); got != want {
+			t.Errorf("Expected %s, got %s", want, got)
+		}
+	})
+
+	t.Run("Group does not repeat the sort fields", func(t *testing.T) {
+		opts := slicer.ParseOpts(url.Values{"group": {"country,city"}, "sort": {"-city"}})
+		values := opts.ToValues()
+		if values.Get("group") != "country,city" || values.Get("select") != "" {
+			t.Errorf("Unexpected values: %v", values)
+		}
+	})
+
+	t.Run("Parser names and separator", func(t *testing.T) {
+		p := &slicer.Parser{Separator: ";", Params: slicer.ParamNames{Page: "p", SearchAnd: "match"}}
+		opts := p.Parse(url.Values{"p": {"3"}, "sort": {"a;-b"}, "match.name": {"x"}, "id[in]": {"1;2"}})
+
+		values := p.ToValues(opts)
+		if values.Get("p") != "3" || values.Get("sort") != "a;-b" || values.Get("match.name") != "x" || values.Get("id[in]") != "1;2" {
+			t.Errorf("Unexpected values: %v", values)
+		}
+		if back := p.Parse(values); !reflect.DeepEqual(back, opts) {
+			t.Errorf("Round trip mismatch:\n got  %+v\n want %+v", back, opts)
+		}
+	})
+}
+
+// TestQueryOptionsEncodeRoundTrip checks that ParseOpts(Encode(o)) == o for
+// randomly generated options of the shape ParseOpts produces.
+func TestQueryOptionsEncodeRoundTrip(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	for i := 0; i < 2000; i++ {
+		opts := randomQueryOptions(rng)
+
+		parsed, err := url.ParseQuery(opts.Encode())
+		if err != nil {
+			t.Fatalf("Encode produced an invalid query string: %v", err)
+		}
+		if got := slicer.ParseOpts(parsed); !reflect.DeepEqual(got, opts) {
+			t.Fatalf("Round trip mismatch for %s:\n got  %#v\n want %#v", opts.Encode(), got, opts)
+		}
+	}
+}
+
+func randomQueryOptions(rng *rand.Rand) slicer.QueryOptions {
+	ident := func() string {
+		const letters = "abcdefghijklmnopqrstuvwxyz_0123456789"
+		b := []byte{letters[rng.Intn(26)]}
+		for n := rng.Intn(6); n > 0; n-- {
+			b = append(b, letters[rng.Intn(len(letters))])
+		}
+		return string(b)
+	}
+	text := func(allowSeparator bool) string {
+		alphabet := []rune("ab Zé9 %&=+?#()'\"\\_-.;:/日本")
+		if allowSeparator {
+			alphabet = append(alphabet, ',')
+		}
+		r := make([]rune, rng.Intn(8))
+		for i := range r {
+			r[i] = alphabet[rng.Intn(len(alphabet))]
+		}
+		return string(r)
+	}
+	nonEmpty := func(allowSeparator bool) string {
+		for {
+			if s := text(allowSeparator); s != "" {
+				return s
+			}
+		}
+	}
+	idents := func(n int) []string {
+		list := make([]string, n)
+		for i := range list {
+			list[i] = ident()
+		}
+		return list
+	}
+
+	opts := slicer.QueryOptions{
+		Page:    1 + rng.Intn(50),
+		Limit:   1 + rng.Intn(100),
+		Filters: map[string]string{},
+	}
+	opts.Offset = (opts.Page - 1) * opts.Limit
+
+	for n := rng.Intn(3); n > 0; n-- {
+		opts.Sort = append(opts.Sort, slicer.SortField{Field: ident(), Desc: rng.Intn(2) == 0})
+	}
+
+	if rng.Intn(2) == 0 {
+		opts.Search = &slicer.SearchQuery{Fields: idents(1 + rng.Intn(3)), Keyword: nonEmpty(true)}
+	}
+
+	if n := rng.Intn(3); n > 0 {
+		seen := map[string]bool{}
+		opts.SearchAnd = &slicer.SearchQueryAnd{}
+		for ; n > 0; n-- {
+			if field := ident(); !seen[field] {
+				seen[field] = true
+				opts.SearchAnd.Fields = append(opts.SearchAnd.Fields, &slicer.SearchField{Field: field, Keyword: nonEmpty(true)})
+			}
+		}
+		sort.Slice(opts.SearchAnd.Fields, func(i, j int) bool {
+			return opts.SearchAnd.Fields[i].Field < opts.SearchAnd.Fields[j].Field
+		})
+	}
+
+	if rng.Intn(3) == 0 {
+		group := idents(1 + rng.Intn(2))
+		opts.Select = append([]string{}, group...)
+		opts.GroupBy = group
+		for _, s := range opts.Sort {
+			opts.GroupBy = append(opts.GroupBy, s.Field)
+		}
+	} else if rng.Intn(2) == 0 {
+		opts.Select = idents(1 + rng.Intn(3))
+	}
+
+	if rng.Intn(4) == 0 {
+		opts.Cursor = &slicer.CursorQuery{After: text(true)}
+		if rng.Intn(2) == 0 {
+			opts.Cursor.Before = nonEmpty(true)
+		}
+	}
+
+	ops := []slicer.ComparisonOp{
+		slicer.GT, slicer.GTE, slicer.LT, slicer.LTE, slicer.EQ, slicer.NE, slicer.IN, slicer.NIN,
+		slicer.BETWEEN, slicer.ISNULL, slicer.NOTNULL, slicer.LIKE, slicer.ILIKE, slicer.PREFIX,
+		slicer.SUFFIX, slicer.REGEX,
+	}
+	value := func(op slicer.ComparisonOp) string {
+		switch op {
+		// list values cannot hold the separator they are joined with
+		case slicer.IN, slicer.NIN:
+			parts := make([]string, 1+rng.Intn(3))
+			for i := range parts {
+				parts[i] = text(false)
+			}
+			return strings.Join(parts, ",")
+		case slicer.BETWEEN:
+			return text(false) + "," + text(false)
+		case slicer.ISNULL, slicer.NOTNULL:
+			return []string{"", "true", "false"}[rng.Intn(3)]
+		default:
+			return text(true)
+		}
+	}
+
+	seen := map[string]bool{}
+	for n := rng.Intn(4); n > 0; n-- {
+		cmp := slicer.ComparisonFilter{Field: ident(), Op: ops[rng.Intn(len(ops))]}
+		cmp.Value = value(cmp.Op)
+		if key := cmp.Field + "[" + string(cmp.Op) + "]"; !seen[key] {
+			seen[key] = true
+			opts.Comparisons = append(opts.Comparisons, cmp)
+		}
+	}
+	sort.Slice(opts.Comparisons, func(i, j int) bool {
+		return opts.Comparisons[i].Field+"["+string(opts.Comparisons[i].Op)+"]" <
+			opts.Comparisons[j].Field+"["+string(opts.Comparisons[j].Op)+"]"
+	})
+
+	for n := rng.Intn(3); n > 0; n-- {
+		opts.Filters["f_"+ident()] = text(true)
+	}
+
+	var expr func(depth int) *slicer.FilterExpr
+	expr = func(depth int) *slicer.FilterExpr {
+		if depth == 0 || rng.Intn(2) == 0 {
+			op := ops[rng.Intn(len(ops))]
+			return slicer.Compare(ident(), op, value(op))
+		}
+		switch rng.Intn(3) {
+		case 0:
+			return &slicer.FilterExpr{Op: slicer.LogicNot, Children: []*slicer.FilterExpr{expr(depth - 1)}}
+		case 1:
+			return &slicer.FilterExpr{Op: slicer.LogicAnd, Children: []*slicer.FilterExpr{expr(depth - 1), expr(depth - 1)}}
+		default:
+			return &slicer.FilterExpr{Op: slicer.LogicOr, Children: []*slicer.FilterExpr{expr(depth - 1)}}
+		}
+	}
+	if rng.Intn(2) == 0 {
+		opts.Filter = expr(3)
+	}
+
+	return opts
+}
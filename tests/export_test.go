@@ -0,0 +1,142 @@
+package slicer_test
+
+import (
+	"bytes"
+	"errors"
+	"net/url"
+	"testing"
+
+	"github.com/godev90/slicer"
+	"github.com/godev90/validator/typedef"
+)
+
+func TestExportFormat(t *testing.T) {
+	t.Run("ParseExportFormat", func(t *testing.T) {
+		tests := map[string]slicer.ExportFormat{
+			"csv":                  slicer.ExportCSV,
+			"CSV":                  slicer.ExportCSV,
+			"text/csv":             slicer.ExportCSV,
+			"jsonl":                slicer.ExportNDJSON,
+			"application/x-ndjson": slicer.ExportNDJSON,
+			"json":                 slicer.ExportJSON,
+		}
+		for input, want := range tests {
+			if got, err := slicer.ParseExportFormat(input); err != nil || got != want {
+				t.Errorf("%s: expected %s, got %s (%v)", input, want, got, err)
+			}
+		}
+		if _, err := slicer.ParseExportFormat("xml"); !errors.Is(err, slicer.ErrUnsupportedFormat) {
+			t.Errorf("Expected ErrUnsupportedFormat, got %v", err)
+		}
+	})
+
+	t.Run("NegotiateExportFormat", func(t *testing.T) {
+		tests := []struct {
+			accept string
+			want   slicer.ExportFormat
+		}{
+			{"", slicer.ExportJSON},
+			{"*/*", slicer.ExportJSON},
+			{"text/csv", slicer.ExportCSV},
+			{"application/json;q=0.5, text/csv", slicer.ExportCSV},
+			{"text/html, application/x-ndjson;q=0.9, */*;q=0.1", slicer.ExportNDJSON},
+		}
+		for _, tt := range tests {
+			if got, err := slicer.NegotiateExportFormat(tt.accept); err != nil || got != tt.want {
+				t.Errorf("%q: expected %s, got %s (%v)", tt.accept, tt.want, got, err)
+			}
+		}
+		if _, err := slicer.NegotiateExportFormat("text/html, image/png"); !errors.Is(err, slicer.ErrUnsupportedFormat) {
+			t.Errorf("Expected ErrUnsupportedFormat, got %v", err)
+		}
+	})
+}
+
+func TestExportPage(t *testing.T) {
+	type order struct {
+		ID     int             `json:"id"`
+		Name   string          `json:"name"`
+		Amount typedef.Float   `json:"amount"`
+		Day    typedef.Date    `json:"day"`
+		Note   *string         `json:"note"`
+		Qty    typedef.Integer `json:"qty"`
+	}
+	var amount typedef.Float
+	amount.Set(12.5)
+	var day typedef.Date
+	day.Set("2024-03-01")
+	var qty typedef.Integer
+	qty.Set(3)
+	note := `say "hi", ok`
+
+	orders := []order{
+		{ID: 1, Name: "Ann", Amount: amount, Day: day, Note: &note, Qty: qty},
+		{ID: 2, Name: "Bob"},
+	}
+	allowed := map[string]string{"id": "id", "name": "name", "amount": "amount", "day": "day", "note": "note", "qty": "qty"}
+	p := slicer.NewSlicePaginator(orders, allowed)
+	opts := slicer.ParseOpts(url.Values{"select": {"name,id,amount,day,note,qty"}})
+	data, _ := slicer.SlicePage(p, opts)
+	columns := slicer.ExportColumns(opts, allowed)
+
+	export := func(format slicer.ExportFormat) string {
+		var buf bytes.Buffer
+		ew, err := slicer.NewExportWriter(&buf, format)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := slicer.ExportPage(ew, data, columns); err != nil {
+			t.Fatal(err)
+		}
+		if err := ew.Close(); err != nil {
+			t.Fatal(err)
+		}
+		return buf.String()
+	}
+
+	t.Run("Columns follow select", func(t *testing.T) {
+		want := []string{"name", "id", "amount", "day", "note", "qty"}
+		if len(columns) != len(want) {
+			t.Fatalf("Expected %v, got %v", want, columns)
+		}
+		for i := range want {
+			if columns[i] != want[i] {
+				t.Fatalf("Expected %v, got %v", want, columns)
+			}
+		}
+		if all := slicer.ExportColumns(slicer.QueryOptions{}, allowed); all[0] != "amount" || len(all) != 6 {
+			t.Errorf("Expected every allowed field sorted, got %v", all)
+		}
+	})
+
+	t.Run("CSV", func(t *testing.T) {
+		want := "name,id,amount,day,note,qty\n" +
+			"Ann,1,12.5,2024-03-01,\"say \"\"hi\"\", ok\",3\n" +
+			"Bob,2,0,,,0\n"
+		if got := export(slicer.ExportCSV); got != want {
+			t.Errorf("Expected\n%s\ngot\n%s", want, got)
+		}
+	})
+
+	t.Run("NDJSON", func(t *testing.T) {
+		want := `{"name":"Ann","id":1,"amount":12.5,"day":"2024-03-01","note":"say \"hi\", ok","qty":3}` + "\n" +
+			`{"name":"Bob","id":2,"amount":0,"day":null,"note":null,"qty":0}` + "\n"
+		if got := export(slicer.ExportNDJSON); got != want {
+			t.Errorf("Expected\n%s\ngot\n%s", want, got)
+		}
+	})
+
+	t.Run("JSON array", func(t *testing.T) {
+		got := export(slicer.ExportJSON)
+		if got[0] != '[' || got[len(got)-2:] != "]\n" || bytes.Count([]byte(got), []byte(`"name"`)) != 2 {
+			t.Errorf("Unexpected JSON export %s", got)
+		}
+
+		var buf bytes.Buffer
+		ew, _ := slicer.NewExportWriter(&buf, slicer.ExportJSON)
+		ew.Close()
+		if buf.String() != "[]\n" {
+			t.Errorf("Expected an empty array, got %q", buf.String())
+		}
+	})
+}
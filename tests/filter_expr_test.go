@@ -0,0 +1,255 @@
+package slicer_test
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/godev90/slicer"
+)
+
+func TestParseFilterExpr(t *testing.T) {
+	t.Run("Nested expression", func(t *testing.T) {
+		expr, err := slicer.ParseFilterExpr("and(or(eq(status,open),eq(status,pending)),not(eq(owner,bot)))")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if expr.Op != slicer.LogicAnd || len(expr.Children) != 2 {
+			t.Fatalf("Unexpected root: %+v", expr)
+		}
+		or := expr.Children[0]
+		if or.Op != slicer.LogicOr || len(or.Children) != 2 || or.Children[1].Comparison.Value != "pending" {
+			t.Errorf("Unexpected or group: %+v", or)
+		}
+		not := expr.Children[1]
+		if not.Op != slicer.LogicNot || not.Children[0].Comparison.Field != "owner" {
+			t.Errorf("Unexpected not group: %+v", not)
+		}
+	})
+
+	t.Run("List operators and quoting", func(t *testing.T) {
+		expr, err := slicer.ParseFilterExpr(`and( in(id, 1, 2 ,3), between(age,18,65), eq(title,'a, (b)'), isnull(deleted_at), eq(note,"it\"s") )`)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		want := []slicer.ComparisonFilter{
+			{Field: "id", Op: slicer.IN, Value: "1,2,3"},
+			{Field: "age", Op: slicer.BETWEEN, Value: "18,65"},
+			{Field: "title", Op: slicer.EQ, Value: "a, (b)"},
+			{Field: "deleted_at", Op: slicer.ISNULL, Value: ""},
+			{Field: "note", Op: slicer.EQ, Value: `it"s`},
+		}
+		for i, w := range want {
+			if got := *expr.Children[i].Comparison; got != w {
+				t.Errorf("Child %d: expected %+v, got %+v", i, w, got)
+			}
+		}
+	})
+
+	t.Run("String round trip", func(t *testing.T) {
+		inputs := []string{
+			"and(or(eq(status,open),eq(status,pending)),not(eq(owner,bot)))",
+			"in(id,1,2,3)",
+			"eq(title,'a, (b)')",
+			"eq(title,'')",
+			"isnull(deleted_at)",
+			"prefix(name,Jo)",
+		}
+		for _, input := range inputs {
+			expr, err := slicer.ParseFilterExpr(input)
+			if err != nil {
+				t.Fatalf("%s: unexpected error: %v", input, err)
+			}
+			if got := expr.String(); got != input {
+				t.Errorf("Expected %q, got %q", input, got)
+			}
+		}
+	})
+
+	t.Run("Syntax errors report position", func(t *testing.T) {
+		tests := []struct {
+			input string
+			pos   int
+		}{
+			{"and(eq(a,1)", 12},
+			{"eq(a,1))", 8},
+			{"near(a,1)", 1},
+			{"and(eq(a,1),foo(b,2))", 13},
+			{"not(eq(a,1),eq(b,2))", 1},
+			{"between(age,1)", 1},
+			{"eq(a,'open)", 6},
+			{"eq(,1)", 4},
+			{"in(name,'a,b','c')", 9},
+			{"between(name,a,'b,c')", 16},
+			{strings.Repeat("not(", 33) + "eq(a,1)" + strings.Repeat(")", 33), 129},
+		}
+		for _, tt := range tests {
+			_, err := slicer.ParseFilterExpr(tt.input)
+			var syntaxErr *slicer.SyntaxError
+			if !errors.As(err, &syntaxErr) {
+				t.Errorf("%s: expected SyntaxError, got %v", tt.input, err)
+				continue
+			}
+			if syntaxErr.Pos != tt.pos {
+				t.Errorf("%s: expected position %d, got %d (%v)", tt.input, tt.pos, syntaxErr.Pos, err)
+			}
+		}
+	})
+
+	t.Run("Nesting depth", func(t *testing.T) {
+		if _, err := slicer.ParseFilterExpr(strings.Repeat("not(", 32) + "eq(a,1)" + strings.Repeat(")", 32)); err != nil {
+			t.Errorf("Expected 32 levels to parse, got %v", err)
+		}
+	})
+
+	t.Run("ParseOpts", func(t *testing.T) {
+		opts := slicer.ParseOpts(url.Values{"filter": {"eq(status,open)"}})
+		if opts.Filter == nil || opts.Filter.Comparison.Value != "open" {
+			t.Errorf("Expected filter expression, got %+v", opts.Filter)
+		}
+		if _, ok := opts.Filters["filter"]; ok {
+			t.Error("filter must not be treated as a plain filter")
+		}
+
+		opts = slicer.ParseOpts(url.Values{"filter": {"eq(status"}})
+		if opts.Filter != nil {
+			t.Error("Malformed expressions should be ignored")
+		}
+	})
+}
+
+func TestSlicePageFilterExpr(t *testing.T) {
+	type Ticket struct {
+		ID     int    `json:"id"`
+		Status string `json:"status"`
+		Owner  string `json:"owner"`
+	}
+
+	tickets := []Ticket{
+		{ID: 1, Status: "open", Owner: "alice"},
+		{ID: 2, Status: "pending", Owner: "bot"},
+		{ID: 3, Status: "closed", Owner: "bob"},
+		{ID: 4, Status: "pending", Owner: "carol"},
+		{ID: 5, Status: "open", Owner: "bot"},
+	}
+
+	run := func(t *testing.T, fields map[string]string, expr *slicer.FilterExpr) []int {
+		t.Helper()
+		paginator := slicer.NewSlicePaginator(tickets, fields)
+		data, err := slicer.SlicePage(paginator, slicer.QueryOptions{Page: 1, Limit: 10, Filter: expr})
+		if err != nil {
+			t.Fatalf("SlicePage failed: %v", err)
+		}
+		var ids []int
+		for _, ticket := range data.Items.([]Ticket) {
+			ids = append(ids, ticket.ID)
+		}
+		return ids
+	}
+
+	equal := func(a, b []int) bool {
+		if len(a) != len(b) {
+			return false
+		}
+		for i := range a {
+			if a[i] != b[i] {
+				return false
+			}
+		}
+		return true
+	}
+
+	fields := slicer.DefaultFilterByJson[Ticket]()
+
+	t.Run("And / Or / Not", func(t *testing.T) {
+		expr, _ := slicer.ParseFilterExpr("and(or(eq(status,open),eq(status,pending)),not(eq(owner,bot)))")
+		if got := run(t, fields, expr); !equal(got, []int{1, 4}) {
+			t.Errorf("Expected [1 4], got %v", got)
+		}
+	})
+
+	t.Run("Disallowed fields are ignored under and()", func(t *testing.T) {
+		limited := map[string]string{"status": "status"}
+		expr := slicer.And(slicer.Compare("status", slicer.EQ, "open"), slicer.Compare("owner", slicer.EQ, "bot"))
+		if got := run(t, limited, expr); !equal(got, []int{1, 5}) {
+			t.Errorf("Expected [1 5], got %v", got)
+		}
+	})
+
+	t.Run("Disallowed fields are rejected under or() and not()", func(t *testing.T) {
+		limited := slicer.NewSlicePaginator(tickets, map[string]string{"status": "status"})
+		for _, expr := range []*slicer.FilterExpr{
+			slicer.And(slicer.Compare("status", slicer.EQ, "open"), slicer.Not(slicer.Compare("owner", slicer.EQ, "bot"))),
+			slicer.Or(slicer.Compare("owner", slicer.EQ, "bob"), slicer.Compare("status", slicer.EQ, "closed")),
+		} {
+			data, err := slicer.SlicePage(limited, slicer.QueryOptions{Page: 1, Limit: 10, Filter: expr})
+			if !errors.Is(err, slicer.ErrFilterField) || data.LastError == nil {
+				t.Errorf("%s: expected ErrFilterField, got %v", expr, err)
+			}
+		}
+	})
+
+	t.Run("Not follows SQL on nulls", func(t *testing.T) {
+		type Row struct {
+			ID    int     `json:"id"`
+			Owner *string `json:"owner"`
+		}
+		bot, alice := "bot", "alice"
+		rows := []Row{{ID: 1, Owner: &bot}, {ID: 2, Owner: &alice}, {ID: 3}}
+		paginator := slicer.NewSlicePaginator(rows, slicer.DefaultFilterByJson[Row]())
+
+		ids := func(expr string) []int {
+			e, err := slicer.ParseFilterExpr(expr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			data, _ := slicer.SlicePage(paginator, slicer.QueryOptions{Page: 1, Limit: 10, Filter: e})
+			var out []int
+			for _, row := range data.Items.([]Row) {
+				out = append(out, row.ID)
+			}
+			return out
+		}
+		for expr, want := range map[string][]int{
+			"not(eq(owner,bot))":                   {2},
+			"not(not(eq(owner,bot)))":              {1},
+			"not(or(eq(owner,bot),eq(id,3)))":      {2},
+			"or(not(eq(owner,bot)),isnull(owner))": {2, 3},
+			"not(ne(owner,bot))":                   {1},
+			"not(and(eq(owner,alice),eq(id,2)))":   {1, 3},
+		} {
+			if got := ids(expr); !equal(got, want) {
+				t.Errorf("%s: expected %v, got %v", expr, want, got)
+			}
+		}
+	})
+
+	t.Run("Regex inside expression honours opt-in", func(t *testing.T) {
+		paginator := slicer.NewSlicePaginator(tickets, fields)
+		_, err := slicer.SlicePage(paginator, slicer.QueryOptions{
+			Page:   1,
+			Limit:  10,
+			Filter: slicer.Or(slicer.Compare("owner", slicer.REGEX, "^b")),
+		})
+		if !errors.Is(err, slicer.ErrRegexDisabled) {
+			t.Errorf("Expected ErrRegexDisabled, got %v", err)
+		}
+	})
+}
+
+func TestFilterExprProtoRoundTrip(t *testing.T) {
+	expr, _ := slicer.ParseFilterExpr("and(or(eq(status,open),in(id,1,2)),not(isnull(owner)))")
+	opts := slicer.QueryOptions{Page: 1, Limit: 10, Filter: expr}
+
+	back := slicer.QueryFromProto(opts.ToProto())
+	if back.Filter.String() != expr.String() {
+		t.Errorf("Expected %s, got %s", expr, back.Filter)
+	}
+
+	if slicer.QueryFromProto(slicer.QueryOptions{Page: 1, Limit: 10}.ToProto()).Filter != nil {
+		t.Error("Expected nil filter")
+	}
+}
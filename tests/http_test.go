@@ -0,0 +1,161 @@
+package slicer_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/godev90/slicer"
+	slicerhttp "github.com/godev90/slicer/http"
+)
+
+func TestHTTPHandler(t *testing.T) {
+	type user struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}
+	users := make([]user, 25)
+	for i := range users {
+		users[i] = user{ID: i + 1, Name: "user"}
+	}
+	allowed := map[string]string{"id": "id", "name": "name"}
+	newPaginator := func(*http.Request) *slicer.SlicePaginator[user] {
+		return slicer.NewSlicePaginator(users, allowed)
+	}
+
+	serve := func(h http.Handler, target string) (*httptest.ResponseRecorder, map[string]any) {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		var body map[string]any
+		if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+			t.Fatalf("Invalid JSON response %q: %v", rec.Body.String(), err)
+		}
+		return rec, body
+	}
+
+	t.Run("Serves pages with headers", func(t *testing.T) {
+		h := slicerhttp.SliceHandler(newPaginator, slicerhttp.Options{})
+		rec, body := serve(h, "/users?page=2&limit=10")
+
+		if rec.Code != http.StatusOK || rec.Header().Get("Content-Type") != "application/json" {
+			t.Errorf("Unexpected response %d %v", rec.Code, rec.Header())
+		}
+		if got := rec.Header().Get("X-Total-Count"); got != "25" {
+			t.Errorf("Expected X-Total-Count 25, got %q", got)
+		}
+		want := `</users?limit=10&page=1>; rel="first", </users?limit=10&page=1>; rel="prev", ` +
+			`</users?limit=10&page=3>; rel="next", </users?limit=10&page=3>; rel="last"`
+		if got := rec.Header().Get("Link"); got != want {
+			t.Errorf("Expected Link %s, got %s", want, got)
+		}
+		if items := body["items"].([]any); len(items) != 10 || body["from"] != 11.0 {
+			t.Errorf("Unexpected body: %v", body)
+		}
+	})
+
+	t.Run("Strict mode rejects invalid parameters", func(t *testing.T) {
+		h := slicerhttp.SliceHandler(newPaginator, slicerhttp.Options{Strict: true})
+		rec, body := serve(h, "/users?sort=email&limit=x")
+
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("Expected 400, got %d", rec.Code)
+		}
+		if errs, ok := body["error"].([]any); !ok || len(errs) != 2 {
+			t.Errorf("Expected two parameter errors, got %v", body["error"])
+		}
+		if rec.Header().Get("Link") != "" {
+			t.Errorf("Error responses must not carry links")
+		}
+	})
+
+	t.Run("Errors use their status code", func(t *testing.T) {
+		limited := func(*http.Request) *slicer.SlicePaginator[user] {
+			return slicer.NewSlicePaginator(users, allowed).
+				Configure(slicer.Config{Limits: slicer.Limits{Mode: slicer.LimitReject, MaxLimit: 5}})
+		}
+		rec, _ := serve(slicerhttp.SliceHandler(limited, slicerhttp.Options{}), "/users?limit=50")
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("Expected 400, got %d", rec.Code)
+		}
+	})
+
+	t.Run("Middleware stores the options", func(t *testing.T) {
+		var got slicer.QueryOptions
+		h := slicerhttp.Middleware(slicerhttp.Options{})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			got, _ = slicerhttp.OptionsFrom(r.Context())
+			page, _ := slicer.SlicePage(newPaginator(r), got)
+			slicerhttp.WritePage(w, r, page)
+		}))
+		rec, _ := serve(h, "/users?limit=3&sort=-id")
+		if got.Limit != 3 || len(got.Sort) != 1 || rec.Code != http.StatusOK {
+			t.Errorf("Unexpected options %+v (%d)", got, rec.Code)
+		}
+
+		strict := slicerhttp.Middleware(slicerhttp.Options{Strict: true, Allowed: allowed})(http.NotFoundHandler())
+		if rec, _ := serve(strict, "/users?sort=email"); rec.Code != http.StatusBadRequest {
+			t.Errorf("Expected 400, got %d", rec.Code)
+		}
+	})
+
+	t.Run("Handlers check stored options against the paginator", func(t *testing.T) {
+		wide := map[string]string{"id": "id", "name": "name", "email": "email"}
+		h := slicerhttp.Middleware(slicerhttp.Options{Strict: true, Allowed: wide})(
+			slicerhttp.SliceHandler(newPaginator, slicerhttp.Options{Strict: true}))
+		if rec, _ := serve(h, "/users?sort=email"); rec.Code != http.StatusBadRequest {
+			t.Errorf("Expected 400, got %d", rec.Code)
+		}
+		if rec, _ := serve(h, "/users?sort=name"); rec.Code != http.StatusOK {
+			t.Errorf("Expected 200, got %d", rec.Code)
+		}
+
+		defer func() {
+			if recover() == nil {
+				t.Error("Expected strict Middleware without Allowed to panic")
+			}
+		}()
+		slicerhttp.Middleware(slicerhttp.Options{Strict: true})
+	})
+
+	t.Run("StatusOf", func(t *testing.T) {
+		if got := slicerhttp.StatusOf(slicer.ParamErrors{{Parameter: "page"}}); got != http.StatusBadRequest {
+			t.Errorf("Expected 400, got %d", got)
+		}
+		if got := slicerhttp.StatusOf(fmt.Errorf("%w: limit 500", slicer.ErrLimitExceeded)); got != http.StatusBadRequest {
+			t.Errorf("Expected 400, got %d", got)
+		}
+		if got := slicerhttp.StatusOf(slicer.ErrUnsupportedFormat); got != http.StatusNotAcceptable {
+			t.Errorf("Expected 406, got %d", got)
+		}
+		if got := slicerhttp.StatusOf(errors.New("connection refused")); got != http.StatusInternalServerError {
+			t.Errorf("Expected 500, got %d", got)
+		}
+	})
+
+	t.Run("Export rejects unsupported formats", func(t *testing.T) {
+		h := slicerhttp.ExportHandler(func(*http.Request) slicer.Paginator[exportModel] {
+			t.Fatal("The paginator must not be built for unsupported formats")
+			return nil
+		}, slicerhttp.Options{})
+
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/orders", nil)
+		req.Header.Set("Accept", "image/png")
+		h.ServeHTTP(rec, req)
+		if rec.Code != http.StatusNotAcceptable {
+			t.Errorf("Expected 406, got %d", rec.Code)
+		}
+
+		rec = httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/orders?format=xml", nil))
+		if rec.Code != http.StatusNotAcceptable {
+			t.Errorf("Expected 406, got %d", rec.Code)
+		}
+	})
+}
+
+type exportModel struct{}
+
+func (exportModel) TableName() string { return "orders" }
@@ -0,0 +1,118 @@
+package slicer_test
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/godev90/slicer"
+)
+
+func TestSlicePageLimits(t *testing.T) {
+	type Row struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+		Tag  string `json:"tag"`
+	}
+
+	rows := make([]Row, 50)
+	for i := range rows {
+		rows[i] = Row{ID: i + 1, Name: "row", Tag: "t"}
+	}
+
+	newPaginator := func(limits slicer.Limits) *slicer.SlicePaginator[Row] {
+		return slicer.NewSlicePaginator(rows, slicer.DefaultFilterByJson[Row]()).
+			Configure(slicer.Config{Limits: limits})
+	}
+
+	t.Run("No limits by default", func(t *testing.T) {
+		data, err := slicer.SlicePage(newPaginator(slicer.Limits{}), slicer.ParseOpts(url.Values{"limit": {"1000"}}))
+		if err != nil || data.Limit != 1000 || len(data.Items.([]Row)) != 50 {
+			t.Errorf("Unexpected result: limit=%d err=%v", data.Limit, err)
+		}
+	})
+
+	t.Run("Clamp limit and page", func(t *testing.T) {
+		paginator := newPaginator(slicer.Limits{MaxLimit: 10, MaxPage: 3})
+
+		data, err := slicer.SlicePage(paginator, slicer.ParseOpts(url.Values{"limit": {"1000"}, "page": {"2"}}))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		items := data.Items.([]Row)
+		if data.Limit != 10 || len(items) != 10 || items[0].ID != 11 {
+			t.Errorf("Expected page 2 of 10 rows, got limit=%d first=%d", data.Limit, items[0].ID)
+		}
+
+		data, err = slicer.SlicePage(paginator, slicer.ParseOpts(url.Values{"page": {"99"}}))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if data.Page != 3 || data.Items.([]Row)[0].ID != 21 {
+			t.Errorf("Expected page clamped to 3, got page %d", data.Page)
+		}
+	})
+
+	t.Run("Reject limit and page", func(t *testing.T) {
+		paginator := newPaginator(slicer.Limits{Mode: slicer.LimitReject, MaxLimit: 10, MaxPage: 3})
+
+		for _, values := range []url.Values{
+			{"limit": {"11"}},
+			{"page": {"4"}},
+		} {
+			data, err := slicer.SlicePage(paginator, slicer.ParseOpts(values))
+			if !errors.Is(err, slicer.ErrLimitExceeded) || data.LastError == nil {
+				t.Errorf("%v: expected ErrLimitExceeded, got %v", values, err)
+			}
+		}
+
+		if _, err := slicer.SlicePage(paginator, slicer.ParseOpts(url.Values{"limit": {"10"}, "page": {"3"}})); err != nil {
+			t.Errorf("Unexpected error at the maximum: %v", err)
+		}
+	})
+
+	t.Run("Filters and comparisons are always rejected", func(t *testing.T) {
+		paginator := newPaginator(slicer.Limits{MaxFilters: 1, MaxComparisons: 2})
+
+		tests := []url.Values{
+			{"name": {"row"}, "search_and.tag": {"t"}},
+			{"id[gt]": {"1"}, "filter": {"and(lt(id,9),ne(id,5))"}},
+		}
+		for _, values := range tests {
+			if _, err := slicer.SlicePage(paginator, slicer.ParseOpts(values)); !errors.Is(err, slicer.ErrLimitExceeded) {
+				t.Errorf("%v: expected ErrLimitExceeded, got %v", values, err)
+			}
+		}
+	})
+
+	t.Run("Clamp sort, search fields and keyword", func(t *testing.T) {
+		paginator := newPaginator(slicer.Limits{MaxSortFields: 1, MaxSearchFields: 1, MaxKeywordLength: 3})
+
+		opts := slicer.ParseOpts(url.Values{
+			"sort":    {"-id,name"},
+			"search":  {"name,tag"},
+			"keyword": {"rowdy"},
+		})
+		data, err := slicer.SlicePage(paginator, opts)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		items := data.Items.([]Row)
+		if data.Total != 50 || items[0].ID != 50 {
+			t.Errorf("Expected all rows sorted by -id, got total=%d first=%d", data.Total, items[0].ID)
+		}
+		if len(opts.Sort) != 2 || opts.Search.Keyword != "rowdy" {
+			t.Errorf("Caller options must not be modified: %+v", opts)
+		}
+	})
+
+	t.Run("Reject long keyword", func(t *testing.T) {
+		paginator := newPaginator(slicer.Limits{Mode: slicer.LimitReject, MaxKeywordLength: 8})
+
+		_, err := slicer.SlicePage(paginator, slicer.ParseOpts(url.Values{"searchAnd.name": {strings.Repeat("x", 9)}}))
+		if !errors.Is(err, slicer.ErrLimitExceeded) {
+			t.Errorf("Expected ErrLimitExceeded, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,117 @@
+package slicer_test
+
+import (
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/godev90/slicer"
+)
+
+func TestPageMetadata(t *testing.T) {
+	type item struct {
+		ID int `json:"id"`
+	}
+	items := make([]item, 25)
+	for i := range items {
+		items[i].ID = i + 1
+	}
+	p := slicer.NewSlicePaginator(items, map[string]string{"id": "id"})
+
+	t.Run("Offset pages", func(t *testing.T) {
+		tests := []struct {
+			page             int
+			totalPages       int64
+			hasNext, hasPrev bool
+			from, to         int64
+		}{
+			{1, 3, true, false, 1, 10},
+			{2, 3, true, true, 11, 20},
+			{3, 3, false, true, 21, 25},
+			{4, 3, false, true, 0, 0},
+		}
+		for _, tt := range tests {
+			data, err := slicer.SlicePage(p, slicer.ParseOpts(url.Values{"page": {strconv.Itoa(tt.page)}}))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if data.TotalPages != tt.totalPages || data.HasNext != tt.hasNext || data.HasPrev != tt.hasPrev ||
+				data.From != tt.from || data.To != tt.to {
+				t.Errorf("Page %d: unexpected metadata %+v", tt.page, data)
+			}
+		}
+	})
+
+	t.Run("Cursor pages", func(t *testing.T) {
+		first, _ := slicer.SlicePage(p, slicer.ParseOpts(url.Values{"cursor": {""}, "sort": {"id"}}))
+		if !first.HasNext || first.HasPrev || first.From != 0 {
+			t.Errorf("Unexpected first page: %+v", first)
+		}
+		second, _ := slicer.SlicePage(p, slicer.ParseOpts(url.Values{"cursor": {first.NextCursor}, "sort": {"id"}}))
+		if !second.HasNext || !second.HasPrev {
+			t.Errorf("Unexpected second page: %+v", second)
+		}
+	})
+}
+
+func TestPageLinks(t *testing.T) {
+	requestURL, _ := url.Parse("https://api.example.com/orders?page=2&limit=10&status=open")
+
+	t.Run("Offset links", func(t *testing.T) {
+		data := slicer.PageData{Page: 2, Limit: 10, Total: 35, TotalPages: 4, HasNext: true, HasPrev: true}.WithLinks(requestURL)
+		want := slicer.PageLinks{
+			Self:  "https://api.example.com/orders?page=2&limit=10&status=open",
+			Next:  "https://api.example.com/orders?limit=10&page=3&status=open",
+			Prev:  "https://api.example.com/orders?limit=10&page=1&status=open",
+			First: "https://api.example.com/orders?limit=10&page=1&status=open",
+			Last:  "https://api.example.com/orders?limit=10&page=4&status=open",
+		}
+		if data.Links == nil || *data.Links != want {
+			t.Errorf("Expected %+v, got %+v", want, data.Links)
+		}
+	})
+
+	t.Run("Unknown total has no last link", func(t *testing.T) {
+		data := slicer.PageData{Page: 2, Limit: 10, Total: -1, TotalPages: -1, HasNext: true, HasPrev: true}.WithLinks(requestURL)
+		if data.Links.Last != "" || data.Links.Next == "" {
+			t.Errorf("Unexpected links: %+v", data.Links)
+		}
+	})
+
+	t.Run("Inexact totals have no last link", func(t *testing.T) {
+		for _, data := range []slicer.PageData{
+			{Page: 2, Limit: 10, Total: 1000, TotalPages: 100, TotalCapped: true, CountStrategy: slicer.CountCapped, HasNext: true},
+			{Page: 2, Limit: 10, Total: 52000, TotalPages: 5200, CountStrategy: slicer.CountEstimated, HasNext: true},
+		} {
+			if links := data.WithLinks(requestURL).Links; links.Last != "" || links.Next == "" {
+				t.Errorf("%s: unexpected links %+v", data.CountStrategy, links)
+			}
+		}
+	})
+
+	t.Run("Cursor links", func(t *testing.T) {
+		u, _ := url.Parse("/orders?cursor=abc&limit=10")
+		data := slicer.PageData{Limit: 10, Total: -1, NextCursor: "n", PrevCursor: "p"}.WithLinks(u)
+		if data.Links.Next != "/orders?cursor=n&limit=10" || data.Links.Prev != "/orders?before=p&limit=10" ||
+			data.Links.First != "/orders?cursor=&limit=10" || data.Links.Last != "" {
+			t.Errorf("Unexpected links: %+v", data.Links)
+		}
+	})
+
+	t.Run("Parser parameter names", func(t *testing.T) {
+		parser := &slicer.Parser{Params: slicer.ParamNames{Page: "p"}}
+		u, _ := url.Parse("/orders?p=1")
+		data := parser.WithLinks(slicer.PageData{Page: 1, Limit: 10, Total: 20, TotalPages: 2, HasNext: true}, u)
+		if data.Links.Next != "/orders?p=2" || data.Links.Prev != "" {
+			t.Errorf("Unexpected links: %+v", data.Links)
+		}
+	})
+
+	t.Run("OData next link with an unknown total", func(t *testing.T) {
+		u, _ := url.Parse("/orders?$top=10")
+		resp := slicer.NewODataResponse(slicer.PageData{Page: 1, Limit: 10, Total: -1, HasNext: true}, u)
+		if resp.NextLink == "" {
+			t.Errorf("Expected a next link")
+		}
+	})
+}
@@ -0,0 +1,257 @@
+package slicer_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/godev90/slicer"
+)
+
+func TestParseOData(t *testing.T) {
+	t.Run("System query options", func(t *testing.T) {
+		opts, err := slicer.ParseOData(url.Values{
+			"$top":     {"20"},
+			"$skip":    {"40"},
+			"$orderby": {"created_at desc, id"},
+			"$select":  {"id, title"},
+			"$count":   {"true"},
+			"status":   {"open"},
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if opts.Limit != 20 || opts.Offset != 40 || opts.Page != 3 {
+			t.Errorf("Unexpected pagination: page=%d limit=%d offset=%d", opts.Page, opts.Limit, opts.Offset)
+		}
+		if len(opts.Sort) != 2 || opts.Sort[0] != (slicer.SortField{Field: "created_at", Desc: true}) || opts.Sort[1] != (slicer.SortField{Field: "id"}) {
+			t.Errorf("Unexpected sort: %+v", opts.Sort)
+		}
+		if len(opts.Select) != 2 || opts.Select[0] != "id" || opts.Select[1] != "title" {
+			t.Errorf("Unexpected select: %v", opts.Select)
+		}
+		if len(opts.Filters) != 1 || opts.Filters["status"] != "open" {
+			t.Errorf("Expected only the plain status filter, got %v", opts.Filters)
+		}
+	})
+
+	t.Run("Skip without top uses the default limit", func(t *testing.T) {
+		opts, err := slicer.ParseOData(url.Values{"$skip": {"25"}})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if opts.Limit != 10 || opts.Offset != 25 || opts.Page != 3 {
+			t.Errorf("Unexpected pagination: page=%d limit=%d offset=%d", opts.Page, opts.Limit, opts.Offset)
+		}
+	})
+
+	t.Run("Filter expressions", func(t *testing.T) {
+		tests := []struct {
+			filter string
+			want   string
+		}{
+			{"status eq 'open'", "eq(status,open)"},
+			{"priority gt 3 and priority le 5", "and(gt(priority,3),lte(priority,5))"},
+			{"status eq 'open' or status eq 'pending' and owner ne 'bot'", "or(eq(status,open),and(eq(status,pending),ne(owner,bot)))"},
+			{"(status eq 'open' or status eq 'pending') and not (owner eq 'bot')", "and(or(eq(status,open),eq(status,pending)),not(eq(owner,bot)))"},
+			{"id in (1, 2, 3)", "in(id,1,2,3)"},
+			{"3 lt priority", "gt(priority,3)"},
+			{"deleted_at eq null", "isnull(deleted_at)"},
+			{"deleted_at ne null", "notnull(deleted_at)"},
+			{"contains(title,'50%')", `like(title,'%50\\%%')`},
+			{"startswith(name,'Jo') or endswith(name,'son')", "or(like(name,Jo%),like(name,%son))"},
+			{"title eq 'it''s'", `eq(title,'it\'s')`},
+			{"created_at ge 2024-01-01T00:00:00Z", "gte(created_at,2024-01-01T00:00:00Z)"},
+			{"active eq true", "eq(active,true)"},
+		}
+		for _, tt := range tests {
+			opts, err := slicer.ParseOData(url.Values{"$filter": {tt.filter}})
+			if err != nil {
+				t.Errorf("%s: unexpected error: %v", tt.filter, err)
+				continue
+			}
+			if got := opts.Filter.String(); got != tt.want {
+				t.Errorf("%s: expected %s, got %s", tt.filter, tt.want, got)
+			}
+		}
+	})
+
+	t.Run("Invalid options", func(t *testing.T) {
+		for _, values := range []url.Values{
+			{"$top": {"ten"}},
+			{"$top": {"0"}},
+			{"$skip": {"-1"}},
+			{"$orderby": {"name sideways"}},
+		} {
+			if _, err := slicer.ParseOData(values); !errors.Is(err, slicer.ErrInvalidODataOption) {
+				t.Errorf("%v: expected ErrInvalidODataOption, got %v", values, err)
+			}
+		}
+	})
+
+	t.Run("Nesting depth", func(t *testing.T) {
+		for _, filter := range []string{
+			strings.Repeat("not ", 32) + "a eq 1",
+			strings.Repeat("(", 32) + "a eq 1" + strings.Repeat(")", 32),
+		} {
+			if _, err := slicer.ParseOData(url.Values{"$filter": {filter}}); err != nil {
+				t.Errorf("Expected 32 levels to parse, got %v", err)
+			}
+		}
+	})
+
+	t.Run("Filter syntax errors point at the offending position", func(t *testing.T) {
+		tests := []struct {
+			filter string
+			pos    int
+		}{
+			{"status = 'open'", 8},
+			{"status eq", 10},
+			{"status eq 'open", 11},
+			{"(status eq 'open'", 18},
+			{"status eq other", 11},
+			{"1 eq 2", 1},
+			{"priority gt null", 10},
+			{"substring(title,1) eq 'a'", 1},
+			{"status eq 'open')", 17},
+			{"name in ('a,b','c')", 10},
+			{strings.Repeat("not ", 33) + "a eq 1", 129},
+			{strings.Repeat("(", 33) + "a eq 1" + strings.Repeat(")", 33), 33},
+		}
+		for _, tt := range tests {
+			_, err := slicer.ParseOData(url.Values{"$filter": {tt.filter}})
+			var syntaxErr *slicer.SyntaxError
+			if !errors.As(err, &syntaxErr) {
+				t.Errorf("%s: expected SyntaxError, got %v", tt.filter, err)
+				continue
+			}
+			if syntaxErr.Pos != tt.pos {
+				t.Errorf("%s: expected position %d, got %d (%v)", tt.filter, tt.pos, syntaxErr.Pos, err)
+			}
+		}
+	})
+}
+
+func TestNewODataResponse(t *testing.T) {
+	requestURL, _ := url.Parse("https://api.example.com/orders?$top=2&$skip=2&$count=true&$filter=status%20eq%20'open'")
+
+	t.Run("Count and next link", func(t *testing.T) {
+		resp := slicer.NewODataResponse(slicer.PageData{Items: []int{3, 4}, Total: 5, Page: 2, Limit: 2}, requestURL)
+
+		want := "https://api.example.com/orders?$count=true&$filter=status+eq+%27open%27&$skip=4&$top=2"
+		if resp.NextLink != want {
+			t.Errorf("Expected next link %s, got %s", want, resp.NextLink)
+		}
+
+		out, err := json.Marshal(resp)
+		if err != nil {
+			t.Fatalf("Marshal failed: %v", err)
+		}
+		var body map[string]any
+		if err := json.Unmarshal(out, &body); err != nil {
+			t.Fatalf("Unmarshal failed: %v", err)
+		}
+		if body["@odata.count"] != float64(5) || body["@odata.nextLink"] != want || len(body["value"].([]any)) != 2 {
+			t.Errorf("Unexpected body: %s", out)
+		}
+	})
+
+	t.Run("Last page has no next link", func(t *testing.T) {
+		lastURL, _ := url.Parse("https://api.example.com/orders?$top=2&$skip=4")
+		resp := slicer.NewODataResponse(slicer.PageData{Items: []int{5}, Total: 5, Page: 3, Limit: 2}, lastURL)
+		if resp.NextLink != "" {
+			t.Errorf("Expected no next link, got %s", resp.NextLink)
+		}
+	})
+
+	t.Run("Count is omitted unless requested", func(t *testing.T) {
+		plain, _ := url.Parse("https://api.example.com/orders")
+		resp := slicer.NewODataResponse(slicer.PageData{Items: []int{1}, Total: 20, Page: 1, Limit: 10}, plain)
+		if resp.Count != nil {
+			t.Errorf("Expected no count, got %d", *resp.Count)
+		}
+		if resp.NextLink != "https://api.example.com/orders?$skip=10&$top=10" {
+			t.Errorf("Unexpected next link: %s", resp.NextLink)
+		}
+	})
+
+	t.Run("Cursor pages link with the next cursor", func(t *testing.T) {
+		resp := slicer.NewODataResponse(slicer.PageData{Items: []int{1}, Total: 20, Limit: 10, NextCursor: "abc.def"}, requestURL)
+		parsed, err := url.Parse(resp.NextLink)
+		if err != nil {
+			t.Fatalf("Invalid next link: %v", err)
+		}
+		if parsed.Query().Get("cursor") != "abc.def" {
+			t.Errorf("Expected cursor in next link, got %s", resp.NextLink)
+		}
+	})
+}
+
+func TestSlicePageOData(t *testing.T) {
+	type Order struct {
+		ID       int    `json:"id"`
+		Status   string `json:"status"`
+		Customer string `json:"customer"`
+	}
+
+	orders := []Order{
+		{ID: 1, Status: "open", Customer: "Acme"},
+		{ID: 2, Status: "closed", Customer: "Globex"},
+		{ID: 3, Status: "open", Customer: "Initech"},
+		{ID: 4, Status: "open", Customer: "Acme Widgets"},
+		{ID: 5, Status: "open", Customer: "Umbrella"},
+	}
+	paginator := slicer.NewSlicePaginator(orders, slicer.DefaultFilterByJson[Order]())
+
+	t.Run("Filter, order and skip", func(t *testing.T) {
+		opts, err := slicer.ParseOData(url.Values{
+			"$filter":  {"status eq 'open'"},
+			"$orderby": {"id desc"},
+			"$top":     {"2"},
+			"$skip":    {"1"},
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		data, err := slicer.SlicePage(paginator, opts)
+		if err != nil {
+			t.Fatalf("SlicePage failed: %v", err)
+		}
+		items := data.Items.([]Order)
+		if data.Total != 4 || len(items) != 2 || items[0].ID != 4 || items[1].ID != 3 {
+			t.Errorf("Expected orders 4 and 3 of 4, got %+v (total %d)", items, data.Total)
+		}
+	})
+
+	t.Run("Search spans the string fields", func(t *testing.T) {
+		opts, err := slicer.ParseODataFor[Order](nil, url.Values{"$search": {"acme"}, "$orderby": {"id"}}, slicer.DefaultFilterByJson[Order]())
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if fields := opts.Search.Fields; len(fields) != 2 || fields[0] != "customer" || fields[1] != "status" {
+			t.Errorf("Expected the customer and status fields, got %v", fields)
+		}
+
+		data, err := slicer.SlicePage(paginator, opts)
+		if err != nil {
+			t.Fatalf("SlicePage failed: %v", err)
+		}
+		items := data.Items.([]Order)
+		if len(items) != 2 || items[0].ID != 1 || items[1].ID != 4 {
+			t.Errorf("Expected orders 1 and 4, got %+v", items)
+		}
+
+		quoted, err := slicer.ParseODataFor[Order](nil, url.Values{"$search": {`"acme widgets"`}}, slicer.DefaultFilterByJson[Order]())
+		if err != nil || quoted.Search.Keyword != "acme widgets" {
+			t.Errorf("Expected the unquoted keyword, got %+v, %v", quoted.Search, err)
+		}
+
+		if _, err := slicer.ParseOData(url.Values{"$search": {"acme"}}); !errors.Is(err, slicer.ErrInvalidODataOption) {
+			t.Errorf("Expected ParseOData to refuse $search without fields, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,109 @@
+package slicer_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	"github.com/godev90/slicer"
+)
+
+func TestParallel(t *testing.T) {
+	numbers := make([]int, 10000)
+	for i := range numbers {
+		numbers[i] = i
+	}
+	ctx := context.Background()
+
+	t.Run("Results keep the input order", func(t *testing.T) {
+		for _, workers := range []int{0, 1, 3, 64} {
+			even, err := slicer.ParallelFilter(ctx, numbers, workers, func(_ context.Context, n int) (bool, error) {
+				return n%2 == 0, nil
+			})
+			if err != nil || !reflect.DeepEqual(even, slicer.Filter(numbers, func(n int) bool { return n%2 == 0 })) {
+				t.Errorf("%d workers: unexpected filter result (%v)", workers, err)
+			}
+
+			squares, err := slicer.ParallelMap(ctx, numbers, workers, func(_ context.Context, n int) (int, error) {
+				return n * n, nil
+			})
+			if err != nil || squares[9999] != 9999*9999 || squares[1] != 1 {
+				t.Errorf("%d workers: unexpected map result (%v)", workers, err)
+			}
+
+			// concatenation is associative but not commutative
+			digits, err := slicer.ParallelReduce(ctx, numbers[:20], workers, "", func(acc string, n int) (string, error) {
+				return acc + strconv.Itoa(n%10), nil
+			}, func(a, b string) string { return a + b })
+			if err != nil || digits != "01234567890123456789" {
+				t.Errorf("%d workers: expected ordered digits, got %q (%v)", workers, digits, err)
+			}
+		}
+	})
+
+	t.Run("Empty input", func(t *testing.T) {
+		out, err := slicer.ParallelMap(ctx, []int{}, 4, func(_ context.Context, n int) (int, error) { return n, nil })
+		if err != nil || len(out) != 0 {
+			t.Errorf("Unexpected result %v (%v)", out, err)
+		}
+		if sum, err := slicer.ParallelReduce(ctx, []int{}, 4, 7, func(acc, n int) (int, error) { return acc + n, nil },
+			func(a, b int) int { return a + b }); err != nil || sum != 7 {
+			t.Errorf("Expected the initial value, got %d (%v)", sum, err)
+		}
+	})
+
+	t.Run("The first error stops the work", func(t *testing.T) {
+		errBoom := errors.New("boom")
+		var calls atomic.Int64
+		out, err := slicer.ParallelMap(ctx, numbers, 4, func(ctx context.Context, n int) (int, error) {
+			calls.Add(1)
+			if n == 100 {
+				return 0, errBoom
+			}
+			return n, nil
+		})
+		if !errors.Is(err, errBoom) || out != nil {
+			t.Errorf("Expected errBoom and no result, got %v", err)
+		}
+		if calls.Load() == int64(len(numbers)) {
+			t.Error("Expected the remaining elements to be skipped")
+		}
+
+		_, err = slicer.ParallelReduce(ctx, numbers, 4, 0, func(acc, n int) (int, error) {
+			if n == 5000 {
+				return 0, errBoom
+			}
+			return acc + n, nil
+		}, func(a, b int) int { return a + b })
+		if !errors.Is(err, errBoom) {
+			t.Errorf("Expected errBoom, got %v", err)
+		}
+	})
+
+	t.Run("Context cancellation", func(t *testing.T) {
+		cancelled, cancel := context.WithCancel(ctx)
+		cancel()
+		_, err := slicer.ParallelFilter(cancelled, numbers, 4, func(context.Context, int) (bool, error) {
+			return true, nil
+		})
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Expected context.Canceled, got %v", err)
+		}
+
+		cancelling, cancel := context.WithCancel(ctx)
+		defer cancel()
+		var calls atomic.Int64
+		_, err = slicer.ParallelMap(cancelling, numbers, 2, func(ctx context.Context, n int) (int, error) {
+			if calls.Add(1) == 10 {
+				cancel()
+			}
+			return n, nil
+		})
+		if !errors.Is(err, context.Canceled) || calls.Load() == int64(len(numbers)) {
+			t.Errorf("Expected cancellation to stop the work, got %v after %d calls", err, calls.Load())
+		}
+	})
+}
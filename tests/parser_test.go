@@ -0,0 +1,172 @@
+package slicer_test
+
+import (
+	"errors"
+	"net/url"
+	"sync"
+	"testing"
+
+	"github.com/godev90/slicer"
+)
+
+func TestParser(t *testing.T) {
+	t.Run("Zero parser matches ParseOpts", func(t *testing.T) {
+		values := url.Values{"page": {"3"}, "sort": {"-age,name"}, "age[gt]": {"30"}, "status": {"open"}}
+		var p slicer.Parser
+		got, want := p.Parse(values), slicer.ParseOpts(values)
+		if got.Page != want.Page || got.Limit != want.Limit || got.Offset != want.Offset ||
+			len(got.Sort) != len(want.Sort) || len(got.Comparisons) != 1 || got.Filters["status"] != "open" {
+			t.Errorf("Expected %+v, got %+v", want, got)
+		}
+		if got.Separator != "" {
+			t.Errorf("Expected empty separator, got %q", got.Separator)
+		}
+	})
+
+	t.Run("Limits", func(t *testing.T) {
+		p := &slicer.Parser{DefaultLimit: 25, MaxLimit: 100}
+		if opts := p.Parse(url.Values{}); opts.Limit != 25 {
+			t.Errorf("Expected default limit 25, got %d", opts.Limit)
+		}
+		if opts := p.Parse(url.Values{"limit": {"1000000"}, "page": {"2"}}); opts.Limit != 100 || opts.Offset != 100 {
+			t.Errorf("Expected limit clamped to 100, got %d (offset %d)", opts.Limit, opts.Offset)
+		}
+
+		_, err := p.ParseStrict(url.Values{"limit": {"500"}}, nil)
+		var errs slicer.ParamErrors
+		if !errors.As(err, &errs) || len(errs) != 1 || errs[0].Reason != "must not exceed 100" {
+			t.Errorf("Expected max limit error, got %v", err)
+		}
+	})
+
+	t.Run("Parameter names", func(t *testing.T) {
+		p := &slicer.Parser{Params: slicer.ParamNames{
+			Page:      "p",
+			Limit:     "per_page",
+			Sort:      "order",
+			SearchAnd: "match",
+		}}
+		opts := p.Parse(url.Values{
+			"p":               {"2"},
+			"per_page":        {"5"},
+			"order":           {"-id"},
+			"match.name":      {"jo"},
+			"searchAnd.title": {"x"},
+			"page":            {"9"},
+		})
+		if opts.Page != 2 || opts.Limit != 5 || len(opts.Sort) != 1 || !opts.Sort[0].Desc {
+			t.Errorf("Unexpected options: %+v", opts)
+		}
+		if opts.SearchAnd == nil || len(opts.SearchAnd.Fields) != 1 || opts.SearchAnd.Fields[0].Field != "name" {
+			t.Errorf("Expected only match.name in search_and, got %+v", opts.SearchAnd)
+		}
+		if opts.Filters["page"] != "9" || opts.Filters["searchAnd.title"] != "x" {
+			t.Errorf("Renamed parameters must not be reserved, got %v", opts.Filters)
+		}
+	})
+
+	t.Run("Allowed operators", func(t *testing.T) {
+		p := &slicer.Parser{Operators: []slicer.ComparisonOp{slicer.EQ, slicer.IN}}
+		opts := p.Parse(url.Values{"id[in]": {"1,2"}, "name[regex]": {".*"}, "filter": {"like(name,a%)"}})
+		if len(opts.Comparisons) != 1 || opts.Comparisons[0].Op != slicer.IN {
+			t.Errorf("Expected only the in comparison, got %+v", opts.Comparisons)
+		}
+		if opts.Filter != nil {
+			t.Errorf("Expected the filter with a disallowed operator to be dropped, got %s", opts.Filter)
+		}
+
+		if _, err := p.ParseRSQL(url.Values{"q": {"age=gt=3"}}); !errors.Is(err, slicer.ErrUnsupportedOperator) {
+			t.Errorf("Expected ErrUnsupportedOperator, got %v", err)
+		}
+
+		_, err := p.ParseStrict(url.Values{"name[regex]": {".*"}}, nil)
+		var errs slicer.ParamErrors
+		if !errors.As(err, &errs) || len(errs) != 1 || errs[0].Reason != `unsupported operator "regex"` {
+			t.Errorf("Expected unsupported operator error, got %v", err)
+		}
+	})
+
+	t.Run("Date layouts", func(t *testing.T) {
+		p := &slicer.Parser{DateLayouts: []string{"02/01/2006", "02/01/2006 15:04"}}
+		opts := p.Parse(url.Values{
+			"created_at[between]": {"01/02/2024,29/02/2024 18:30"},
+			"filter":              {"gte(updated_at,31/01/2024)"},
+		})
+		if len(opts.Comparisons) != 1 || opts.Comparisons[0].Value != "2024-02-01,2024-02-29T18:30:00Z" {
+			t.Errorf("Unexpected comparisons: %+v", opts.Comparisons)
+		}
+		if got := opts.Filter.String(); got != "gte(updated_at,2024-01-31)" {
+			t.Errorf("Unexpected filter: %s", got)
+		}
+	})
+
+	t.Run("Independent separators", func(t *testing.T) {
+		semicolon := &slicer.Parser{Separator: ";"}
+		comma := &slicer.Parser{}
+
+		var wg sync.WaitGroup
+		for i := 0; i < 20; i++ {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				if opts := semicolon.Parse(url.Values{"sort": {"a;b"}}); len(opts.Sort) != 2 {
+					t.Errorf("Expected 2 sort fields, got %+v", opts.Sort)
+				}
+			}()
+			go func() {
+				defer wg.Done()
+				if opts := comma.Parse(url.Values{"sort": {"a,b"}}); len(opts.Sort) != 2 {
+					t.Errorf("Expected 2 sort fields, got %+v", opts.Sort)
+				}
+			}()
+		}
+		wg.Wait()
+	})
+
+	t.Run("SetValueSeparator snapshots into ParseOpts", func(t *testing.T) {
+		slicer.SetValueSeparator("|")
+		opts := slicer.ParseOpts(url.Values{"sort": {"a|b"}})
+		slicer.SetValueSeparator(",")
+
+		if len(opts.Sort) != 2 || opts.Separator != "|" {
+			t.Errorf("Expected 2 sort fields split by |, got %+v", opts)
+		}
+		if opts := slicer.ParseOpts(url.Values{"sort": {"a,b"}}); len(opts.Sort) != 2 || opts.Separator != "" {
+			t.Errorf("Expected the default separator back, got %+v", opts)
+		}
+	})
+}
+
+func TestSlicePageParserSeparator(t *testing.T) {
+	type City struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}
+
+	cities := []City{
+		{ID: 1, Name: "Paris"},
+		{ID: 2, Name: "Washington, D.C."},
+		{ID: 3, Name: "Rome"},
+	}
+	paginator := slicer.NewSlicePaginator(cities, slicer.DefaultFilterByJson[City]())
+
+	p := &slicer.Parser{Separator: "|"}
+	opts := p.Parse(url.Values{"name[in]": {"Washington, D.C.|Rome"}, "sort": {"id"}})
+	if opts.Separator != "|" {
+		t.Fatalf("Expected the separator to be recorded, got %q", opts.Separator)
+	}
+
+	data, err := slicer.SlicePage(paginator, opts)
+	if err != nil {
+		t.Fatalf("SlicePage failed: %v", err)
+	}
+	items := data.Items.([]City)
+	if len(items) != 2 || items[0].ID != 2 || items[1].ID != 3 {
+		t.Errorf("Expected cities 2 and 3, got %+v", items)
+	}
+
+	back := slicer.QueryFromProto(opts.ToProto())
+	if back.Separator != "|" || back.Comparisons[0].Value != "Washington, D.C.|Rome" {
+		t.Errorf("Separator lost in proto round trip: %+v", back)
+	}
+}
@@ -0,0 +1,115 @@
+package slicer_test
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+
+	"github.com/godev90/slicer"
+)
+
+func TestParseOptsPatternOperators(t *testing.T) {
+	opts := slicer.ParseOpts(url.Values{
+		"name[prefix]":  {"Jo"},
+		"email[suffix]": {"@corp.com"},
+		"code[regex]":   {`^A\d+$`},
+		"title[like]":   {"%go%"},
+		"title[ilike]":  {"%GO%"},
+	})
+
+	if len(opts.Comparisons) != 5 {
+		t.Fatalf("Expected 5 comparisons, got %d: %+v", len(opts.Comparisons), opts.Comparisons)
+	}
+	if len(opts.Filters) != 0 {
+		t.Errorf("Expected no plain filters, got %v", opts.Filters)
+	}
+}
+
+func TestSlicePagePatternOperators(t *testing.T) {
+	type Person struct {
+		ID    int    `json:"id"`
+		Name  string `json:"name"`
+		Email string `json:"email"`
+		Code  string `json:"code"`
+	}
+
+	people := []Person{
+		{ID: 1, Name: "John", Email: "john@corp.com", Code: "A12"},
+		{ID: 2, Name: "joanna", Email: "jo@mail.com", Code: "B7"},
+		{ID: 3, Name: "Bob_Jo", Email: "bob@CORP.com", Code: "A1x"},
+		{ID: 4, Name: "Alice", Email: "alice@corp.com.au", Code: "A99"},
+	}
+	fields := slicer.DefaultFilterByJson[Person]()
+
+	run := func(t *testing.T, paginator *slicer.SlicePaginator[Person], cmp slicer.ComparisonFilter) ([]int, error) {
+		t.Helper()
+		data, err := slicer.SlicePage(paginator, slicer.QueryOptions{
+			Page:        1,
+			Limit:       10,
+			Comparisons: []slicer.ComparisonFilter{cmp},
+		})
+		var ids []int
+		if items, ok := data.Items.([]Person); ok {
+			for _, p := range items {
+				ids = append(ids, p.ID)
+			}
+		}
+		return ids, err
+	}
+
+	tests := []struct {
+		name string
+		cmp  slicer.ComparisonFilter
+		want []int
+	}{
+		{"prefix is case-insensitive", slicer.ComparisonFilter{Field: "name", Op: slicer.PREFIX, Value: "jo"}, []int{1, 2}},
+		{"suffix", slicer.ComparisonFilter{Field: "email", Op: slicer.SUFFIX, Value: "@corp.com"}, []int{1, 3}},
+		{"like is case-sensitive", slicer.ComparisonFilter{Field: "name", Op: slicer.LIKE, Value: "J%"}, []int{1}},
+		{"like single character wildcard", slicer.ComparisonFilter{Field: "code", Op: slicer.LIKE, Value: "A__"}, []int{1, 3, 4}},
+		{"like escaped underscore", slicer.ComparisonFilter{Field: "name", Op: slicer.LIKE, Value: `%\_%`}, []int{3}},
+		{"ilike", slicer.ComparisonFilter{Field: "email", Op: slicer.ILIKE, Value: "%@CORP.COM"}, []int{1, 3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := run(t, slicer.NewSlicePaginator(people, fields), tt.cmp)
+			if err != nil {
+				t.Fatalf("SlicePage failed: %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("Expected %v, got %v", tt.want, got)
+			}
+			for i := range tt.want {
+				if got[i] != tt.want[i] {
+					t.Fatalf("Expected %v, got %v", tt.want, got)
+				}
+			}
+		})
+	}
+
+	t.Run("regex is disabled by default", func(t *testing.T) {
+		_, err := run(t, slicer.NewSlicePaginator(people, fields), slicer.ComparisonFilter{Field: "code", Op: slicer.REGEX, Value: `^A\d+$`})
+		if !errors.Is(err, slicer.ErrRegexDisabled) {
+			t.Errorf("Expected ErrRegexDisabled, got %v", err)
+		}
+	})
+
+	t.Run("regex when allowed", func(t *testing.T) {
+		paginator := slicer.NewSlicePaginator(people, fields).Configure(slicer.Config{AllowRegex: true})
+		got, err := run(t, paginator, slicer.ComparisonFilter{Field: "code", Op: slicer.REGEX, Value: `^A\d+$`})
+		if err != nil {
+			t.Fatalf("SlicePage failed: %v", err)
+		}
+		if len(got) != 2 || got[0] != 1 || got[1] != 4 {
+			t.Errorf("Expected [1 4], got %v", got)
+		}
+	})
+
+	t.Run("invalid regex is rejected", func(t *testing.T) {
+		paginator := slicer.NewSlicePaginator(people, fields).Configure(slicer.Config{AllowRegex: true})
+		_, err := run(t, paginator, slicer.ComparisonFilter{Field: "code", Op: slicer.REGEX, Value: `(`})
+		if !errors.Is(err, slicer.ErrInvalidRegex) {
+			t.Errorf("Expected ErrInvalidRegex, got %v", err)
+		}
+	})
+}
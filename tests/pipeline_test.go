@@ -0,0 +1,98 @@
+package slicer_test
+
+import (
+	"cmp"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/godev90/slicer"
+)
+
+func TestPipeline(t *testing.T) {
+	numbers := make([]int, 100000)
+	for i := range numbers {
+		numbers[i] = i
+	}
+	even := func(n int) bool { return n%2 == 0 }
+
+	t.Run("Stages are lazy", func(t *testing.T) {
+		calls := 0
+		got := slicer.NewPipeline(numbers).
+			Filter(func(n int) bool { calls++; return even(n) }).
+			Skip(2).
+			Take(3).
+			Collect()
+		if !reflect.DeepEqual(got, []int{4, 6, 8}) {
+			t.Errorf("Expected [4 6 8], got %v", got)
+		}
+		if calls != 9 {
+			t.Errorf("Expected the source to be read up to 8 (9 calls), got %d calls", calls)
+		}
+	})
+
+	t.Run("Map, Distinct, SortBy and Reduce", func(t *testing.T) {
+		words := slicer.NewPipeline([]string{"pear", "fig", "apple", "fig", "kiwi"}).
+			Distinct(nil).
+			SortBy(func(a, b string) int { return cmp.Compare(len(a), len(b)) })
+		if got := words.Collect(); !reflect.DeepEqual(got, []string{"fig", "pear", "kiwi", "apple"}) {
+			t.Errorf("Unexpected order %v", got)
+		}
+
+		lengths := slicer.MapPipeline(words, func(s string) int { return len(s) })
+		if sum := slicer.ReducePipeline(lengths, 0, func(acc, n int) int { return acc + n }); sum != 16 {
+			t.Errorf("Expected 16, got %d", sum)
+		}
+
+		byParity := slicer.NewPipeline([]int{1, 3, 2, 5, 4}).Distinct(func(n int) any { return n % 2 }).Collect()
+		if !reflect.DeepEqual(byParity, []int{1, 2}) {
+			t.Errorf("Expected [1 2], got %v", byParity)
+		}
+		if got := slicer.NewPipeline([]int{}).Collect(); got == nil || len(got) != 0 {
+			t.Errorf("Expected an empty slice, got %v", got)
+		}
+	})
+
+	t.Run("Page counts exactly on request", func(t *testing.T) {
+		data, err := slicer.NewPipeline(numbers).Filter(even).Page(slicer.QueryOptions{Page: 2, Limit: 10, Count: slicer.CountExact})
+		if err != nil {
+			t.Fatal(err)
+		}
+		items := data.Items.([]int)
+		if len(items) != 10 || items[0] != 20 || data.Total != 50000 || !data.HasMore || data.TotalPages != 5000 {
+			t.Errorf("Unexpected page %+v", data)
+		}
+	})
+
+	t.Run("Page without a total reads past the page only", func(t *testing.T) {
+		calls := 0
+		data, _ := slicer.NewPipeline(numbers).
+			Filter(func(n int) bool { calls++; return even(n) }).
+			Page(slicer.QueryOptions{Page: 1, Limit: 5})
+		if data.Total != -1 || !data.HasMore || len(data.Items.([]int)) != 5 {
+			t.Errorf("Unexpected page %+v", data)
+		}
+		if calls != 11 || data.CountStrategy != slicer.CountNone {
+			t.Errorf("Expected 11 predicate calls without a count, got %d (%s)", calls, data.CountStrategy)
+		}
+
+		capped, _ := slicer.NewPipeline(numbers).Page(slicer.QueryOptions{Page: 1, Limit: 5, Count: slicer.CountCapped})
+		if capped.Total != 1000 || !capped.TotalCapped || capped.TotalString() != "1000+" {
+			t.Errorf("Unexpected capped page %+v", capped)
+		}
+	})
+
+	t.Run("Paginator applies query options", func(t *testing.T) {
+		type item struct {
+			Name string `json:"name"`
+		}
+		source := slicer.MapPipeline(slicer.NewPipeline(numbers).Take(30), func(n int) item {
+			return item{Name: "item" + strconv.Itoa(n)}
+		})
+		p := source.Paginator(map[string]string{"name": "name"})
+		data, _ := slicer.SlicePage(p, slicer.QueryOptions{Page: 1, Limit: 10, Search: &slicer.SearchQuery{Fields: []string{"name"}, Keyword: "item2"}})
+		if data.Total != 11 {
+			t.Errorf("Expected 11 matches, got %d", data.Total)
+		}
+	})
+}
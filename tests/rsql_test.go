@@ -0,0 +1,172 @@
+package slicer_test
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/godev90/slicer"
+)
+
+func TestParseRSQL(t *testing.T) {
+	t.Run("Precedence of and over or", func(t *testing.T) {
+		opts, err := slicer.ParseRSQL(url.Values{
+			"q":     {"status==open;priority=gt=3,owner==alice"},
+			"page":  {"2"},
+			"limit": {"5"},
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		want := "or(and(eq(status,open),gt(priority,3)),eq(owner,alice))"
+		if got := opts.Filter.String(); got != want {
+			t.Errorf("Expected %s, got %s", want, got)
+		}
+		if opts.Page != 2 || opts.Limit != 5 || opts.Offset != 5 {
+			t.Errorf("Unexpected pagination: %+v", opts)
+		}
+		if len(opts.Filters) != 0 {
+			t.Errorf("q must not leak into filters: %v", opts.Filters)
+		}
+	})
+
+	t.Run("Operators, groups and keywords", func(t *testing.T) {
+		tests := []struct {
+			q    string
+			want string
+		}{
+			{"age=ge=18 and age<65", "and(gte(age,18),lt(age,65))"},
+			{"(status==open,status==pending);owner!=bot", "and(or(eq(status,open),eq(status,pending)),ne(owner,bot))"},
+			{"id=in=(1,2,3)", "in(id,1,2,3)"},
+			{"id=out=(4, 5)", "nin(id,4,5)"},
+			{"age=between=(18,65)", "between(age,18,65)"},
+			{"deleted_at=isnull=true", "isnull(deleted_at,true)"},
+			{"title=='hello, world'", "eq(title,'hello, world')"},
+			{"name==Jo*", `like(name,Jo%)`},
+			{"name!=*_bot", `or(not(like(name,'%\\_bot')),isnull(name))`},
+			{"name=='Jo*'", "eq(name,Jo*)"},
+			{"name=prefix=Jo or name=suffix=son", "or(prefix(name,Jo),suffix(name,son))"},
+		}
+		for _, tt := range tests {
+			opts, err := slicer.ParseRSQL(url.Values{"q": {tt.q}})
+			if err != nil {
+				t.Errorf("%s: unexpected error: %v", tt.q, err)
+				continue
+			}
+			if got := opts.Filter.String(); got != tt.want {
+				t.Errorf("%s: expected %s, got %s", tt.q, tt.want, got)
+			}
+		}
+	})
+
+	t.Run("Combined with filter parameter", func(t *testing.T) {
+		opts, err := slicer.ParseRSQL(url.Values{"q": {"a==1"}, "filter": {"eq(b,2)"}})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if got := opts.Filter.String(); got != "and(eq(b,2),eq(a,1))" {
+			t.Errorf("Unexpected filter: %s", got)
+		}
+	})
+
+	t.Run("Empty query", func(t *testing.T) {
+		opts, err := slicer.ParseRSQL(url.Values{"sort": {"-id"}})
+		if err != nil || opts.Filter != nil || len(opts.Sort) != 1 {
+			t.Errorf("Unexpected result: %+v, %v", opts, err)
+		}
+	})
+
+	t.Run("Nesting depth", func(t *testing.T) {
+		if _, err := slicer.ParseRSQL(url.Values{"q": {strings.Repeat("(", 32) + "a==1" + strings.Repeat(")", 32)}}); err != nil {
+			t.Errorf("Expected 32 levels to parse, got %v", err)
+		}
+	})
+
+	t.Run("Syntax errors point at the offending position", func(t *testing.T) {
+		tests := []struct {
+			q   string
+			pos int
+		}{
+			{"status=open", 7},
+			{"status==open;", 14},
+			{"(status==open", 14},
+			{"age=foo=3", 4},
+			{"==open", 1},
+			{"id=in=(1,2", 11},
+			{"title=='open", 8},
+			{"age=between=(1)", 4},
+			{"status==open)", 13},
+			{`name=in=("a,b","c")`, 10},
+			{strings.Repeat("(", 33) + "a==1" + strings.Repeat(")", 33), 33},
+		}
+		for _, tt := range tests {
+			_, err := slicer.ParseRSQL(url.Values{"q": {tt.q}})
+			var syntaxErr *slicer.SyntaxError
+			if !errors.As(err, &syntaxErr) {
+				t.Errorf("%s: expected SyntaxError, got %v", tt.q, err)
+				continue
+			}
+			if syntaxErr.Pos != tt.pos {
+				t.Errorf("%s: expected position %d, got %d (%v)", tt.q, tt.pos, syntaxErr.Pos, err)
+			}
+		}
+	})
+}
+
+func TestSlicePageRSQL(t *testing.T) {
+	type Issue struct {
+		ID       int    `json:"id"`
+		Status   string `json:"status"`
+		Priority int    `json:"priority"`
+		Owner    string `json:"owner"`
+	}
+
+	issues := []Issue{
+		{ID: 1, Status: "open", Priority: 5, Owner: "bob"},
+		{ID: 2, Status: "open", Priority: 1, Owner: "alice"},
+		{ID: 3, Status: "closed", Priority: 4, Owner: "carol"},
+		{ID: 4, Status: "open", Priority: 2, Owner: "dave"},
+	}
+
+	opts, err := slicer.ParseRSQL(url.Values{"q": {"status==open;priority=gt=3,owner==alice"}, "sort": {"id"}})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	paginator := slicer.NewSlicePaginator(issues, slicer.DefaultFilterByJson[Issue]())
+	data, err := slicer.SlicePage(paginator, opts)
+	if err != nil {
+		t.Fatalf("SlicePage failed: %v", err)
+	}
+
+	items := data.Items.([]Issue)
+	if len(items) != 2 || items[0].ID != 1 || items[1].ID != 2 {
+		t.Errorf("Expected issues 1 and 2, got %+v", items)
+	}
+
+	t.Run("Not equal matches NULL", func(t *testing.T) {
+		type Task struct {
+			ID       int     `json:"id"`
+			Assignee *string `json:"assignee"`
+		}
+		bob, bot := "bob", "build-bot"
+		paginator := slicer.NewSlicePaginator([]Task{{ID: 1, Assignee: &bob}, {ID: 2, Assignee: &bot}, {ID: 3}}, slicer.DefaultFilterByJson[Task]())
+
+		for _, q := range []string{"assignee!=build-bot", "assignee!=*bot"} {
+			opts, err := slicer.ParseRSQL(url.Values{"q": {q}, "sort": {"id"}})
+			if err != nil {
+				t.Fatalf("%s: unexpected error: %v", q, err)
+			}
+			data, err := slicer.SlicePage(paginator, opts)
+			if err != nil {
+				t.Fatalf("%s: SlicePage failed: %v", q, err)
+			}
+			items := data.Items.([]Task)
+			if len(items) != 2 || items[0].ID != 1 || items[1].ID != 3 {
+				t.Errorf("%s: expected tasks 1 and 3, got %+v", q, items)
+			}
+		}
+	})
+}
@@ -0,0 +1,62 @@
+package slicer_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/godev90/slicer"
+)
+
+type benchRow struct {
+	ID     int     `json:"id"`
+	Name   string  `json:"name"`
+	Team   string  `json:"team"`
+	Score  float64 `json:"score"`
+	Region string  `json:"region"`
+	Notes  string  `json:"notes"`
+	Active bool    `json:"active"`
+}
+
+func benchRows(n int) []benchRow {
+	teams := []string{"core", "web", "ops", "data"}
+	rows := make([]benchRow, n)
+	for i := range rows {
+		rows[i] = benchRow{
+			ID:     i,
+			Name:   "user" + strconv.Itoa(i),
+			Team:   teams[i%len(teams)],
+			Score:  float64((i * 7919) % 1000),
+			Region: "eu",
+			Active: i%3 == 0,
+		}
+	}
+	return rows
+}
+
+// BenchmarkSlicePage runs SlicePage over 100k rows. Every sub-benchmark
+// looks fields up once or twice per row, so it tracks the cost of
+// findFieldByColumn.
+func BenchmarkSlicePage(b *testing.B) {
+	rows := benchRows(100_000)
+	allowed := slicer.DefaultFilterByJson[benchRow]()
+
+	benchmarks := map[string]slicer.QueryOptions{
+		"Comparisons": {Page: 1, Limit: 20, Comparisons: []slicer.ComparisonFilter{
+			{Field: "score", Op: slicer.GTE, Value: "500"},
+			{Field: "team", Op: slicer.EQ, Value: "core"},
+		}},
+		"Search": {Page: 1, Limit: 20, Search: &slicer.SearchQuery{Fields: []string{"name"}, Keyword: "user99"}},
+		"Sort":   {Page: 1, Limit: 20, Sort: []slicer.SortField{{Field: "score", Desc: true}}},
+	}
+	for name, opts := range benchmarks {
+		b.Run(name, func(b *testing.B) {
+			p := slicer.NewSlicePaginator(rows, allowed)
+			b.ReportAllocs()
+			for range b.N {
+				if _, err := slicer.SlicePage(p, opts); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
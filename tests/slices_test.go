@@ -0,0 +1,109 @@
+package slicer_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/godev90/slicer"
+)
+
+func TestSliceHelpers(t *testing.T) {
+	type user struct {
+		Name string
+		Age  int
+		Team string
+	}
+	users := []user{
+		{"Ann", 31, "core"},
+		{"Bob", 25, "web"},
+		{"Cid", 25, "core"},
+		{"Dee", 40, "ops"},
+	}
+	original := append([]user(nil), users...)
+	adult := func(u user) bool { return u.Age >= 30 }
+	age := func(u user) int { return u.Age }
+	name := func(u user) string { return u.Name }
+
+	equal := func(t *testing.T, got, want any) {
+		t.Helper()
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("Expected %v, got %v", want, got)
+		}
+	}
+
+	t.Run("Filter, Map and Reduce", func(t *testing.T) {
+		equal(t, slicer.Map(slicer.Filter(users, adult), name), []string{"Ann", "Dee"})
+		equal(t, slicer.Filter(users, func(user) bool { return false }), []user{})
+		equal(t, slicer.Reduce(users, 0, func(sum int, u user) int { return sum + u.Age }), 121)
+		equal(t, slicer.Reduce(users, "", func(s string, u user) string { return s + u.Name[:1] }), "ABCD")
+	})
+
+	t.Run("Predicates", func(t *testing.T) {
+		if !slicer.Any(users, adult) || slicer.All(users, adult) || !slicer.All([]user{}, adult) {
+			t.Error("Unexpected Any/All result")
+		}
+		if u, ok := slicer.Find(users, adult); !ok || u.Name != "Ann" {
+			t.Errorf("Expected Ann, got %v", u)
+		}
+		if _, ok := slicer.Find(users, func(u user) bool { return u.Age > 99 }); ok {
+			t.Error("Expected no match")
+		}
+		if !slicer.Exists([]string{"a", "b"}, "b") || slicer.Exists([]string{"a"}, "c") {
+			t.Error("Unexpected Exists result")
+		}
+	})
+
+	t.Run("Chunk, Unique and Reverse", func(t *testing.T) {
+		equal(t, slicer.Chunk([]int{1, 2, 3, 4, 5}, 2), [][]int{{1, 2}, {3, 4}, {5}})
+		equal(t, slicer.Chunk([]int{}, 3), [][]int{})
+		equal(t, slicer.Unique([]int{3, 1, 3, 2, 1}), []int{3, 1, 2})
+		equal(t, slicer.Reverse([]int{1, 2, 3}), []int{3, 2, 1})
+
+		defer func() {
+			if recover() == nil {
+				t.Error("Expected Chunk to panic on a size of 0")
+			}
+		}()
+		slicer.Chunk([]int{1}, 0)
+	})
+
+	t.Run("Grouping", func(t *testing.T) {
+		groups := slicer.GroupBy(users, func(u user) string { return u.Team })
+		equal(t, slicer.Map(groups["core"], name), []string{"Ann", "Cid"})
+		equal(t, len(groups), 3)
+
+		adults, young := slicer.Partition(users, adult)
+		equal(t, slicer.Map(adults, name), []string{"Ann", "Dee"})
+		equal(t, slicer.Map(young, name), []string{"Bob", "Cid"})
+	})
+
+	t.Run("Zip and Flatten", func(t *testing.T) {
+		equal(t, slicer.Zip([]int{1, 2, 3}, []string{"a", "b"}), []slicer.Pair[int, string]{{First: 1, Second: "a"}, {First: 2, Second: "b"}})
+		equal(t, slicer.Flatten([][]int{{1}, {}, {2, 3}}), []int{1, 2, 3})
+	})
+
+	t.Run("Ordering", func(t *testing.T) {
+		equal(t, slicer.Map(slicer.SortBy(users, age), name), []string{"Bob", "Cid", "Ann", "Dee"})
+		equal(t, slicer.Map(slicer.SortBy(users, func(u user) string { return strings.ToLower(u.Team) }), name),
+			[]string{"Ann", "Cid", "Dee", "Bob"})
+
+		if u, ok := slicer.MinBy(users, age); !ok || u.Name != "Bob" {
+			t.Errorf("Expected the first youngest user Bob, got %v", u)
+		}
+		if u, ok := slicer.MaxBy(users, age); !ok || u.Name != "Dee" {
+			t.Errorf("Expected Dee, got %v", u)
+		}
+		if _, ok := slicer.MaxBy([]user{}, age); ok {
+			t.Error("Expected no maximum of an empty slice")
+		}
+	})
+
+	t.Run("Input is not modified", func(t *testing.T) {
+		slicer.SortBy(users, age)
+		slicer.Reverse(users)
+		chunks := slicer.Chunk(users, 2)
+		chunks[0][0].Name = "changed"
+		equal(t, users, original)
+	})
+}
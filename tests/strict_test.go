@@ -0,0 +1,123 @@
+package slicer_test
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/godev90/slicer"
+	"github.com/godev90/validator/faults"
+)
+
+func TestParseOptsStrict(t *testing.T) {
+	allowed := map[string]string{
+		"id":         "id",
+		"name":       "name",
+		"age":        "age",
+		"created_at": "created_at",
+		"deleted_at": "deleted_at",
+	}
+
+	t.Run("Valid input", func(t *testing.T) {
+		opts, err := slicer.ParseOptsStrict(url.Values{
+			"page":               {"2"},
+			"limit":              {"20"},
+			"sort":               {"-created_at,id"},
+			"search":             {"name"},
+			"keyword":            {"jo"},
+			"searchAnd.name":     {"doe"},
+			"age[between]":       {"18,65"},
+			"created_at[gte]":    {"2024-01-31"},
+			"deleted_at[isnull]": {"true"},
+			"name":               {"John"},
+			"filter":             {"or(eq(age,30),in(id,1,2))"},
+			"cursor":             {""},
+		}, allowed)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if opts.Page != 2 || opts.Limit != 20 || len(opts.Comparisons) != 3 || opts.Filter == nil {
+			t.Errorf("Unexpected options: %+v", opts)
+		}
+	})
+
+	t.Run("Every problem is reported", func(t *testing.T) {
+		_, err := slicer.ParseOptsStrict(url.Values{
+			"page":               {"two"},
+			"limit":              {"0"},
+			"sort":               {"-password"},
+			"age[approx]":        {"30"},
+			"created_at[gt]":     {"2024-02-30"},
+			"age[between]":       {"18"},
+			"deleted_at[isnull]": {"maybe"},
+			"role":               {"admin"},
+			"search_and.role":    {"x"},
+			"filter":             {"eq(age,30"},
+		}, allowed)
+
+		var errs slicer.ParamErrors
+		if !errors.As(err, &errs) {
+			t.Fatalf("Expected ParamErrors, got %v", err)
+		}
+
+		want := []slicer.ParamError{
+			{Parameter: "age[approx]", Field: "age", Reason: `unsupported operator "approx"`},
+			{Parameter: "age[between]", Field: "age", Reason: "between takes exactly two values"},
+			{Parameter: "created_at[gt]", Field: "created_at", Reason: `invalid date "2024-02-30"`},
+			{Parameter: "deleted_at[isnull]", Field: "deleted_at", Reason: "must be a boolean"},
+			{Parameter: "filter", Reason: `expected ')', found end of input at position 10`},
+			{Parameter: "limit", Reason: "must be a positive integer"},
+			{Parameter: "page", Reason: "must be a positive integer"},
+			{Parameter: "role", Field: "role", Reason: "unknown field"},
+			{Parameter: "search_and.role", Field: "role", Reason: "unknown field"},
+			{Parameter: "sort", Field: "password", Reason: "unknown field"},
+		}
+		if len(errs) != len(want) {
+			t.Fatalf("Expected %d errors, got %d: %v", len(want), len(errs), errs)
+		}
+		for i := range want {
+			if errs[i] != want[i] {
+				t.Errorf("Error %d: expected %+v, got %+v", i, want[i], errs[i])
+			}
+		}
+	})
+
+	t.Run("Filter expression fields are checked", func(t *testing.T) {
+		_, err := slicer.ParseOptsStrict(url.Values{"filter": {"and(eq(name,x),eq(secret,y))"}}, allowed)
+		var errs slicer.ParamErrors
+		if !errors.As(err, &errs) || len(errs) != 1 || errs[0].Field != "secret" {
+			t.Errorf("Expected unknown field secret, got %v", err)
+		}
+	})
+
+	t.Run("Nil allowed map skips field checks", func(t *testing.T) {
+		if _, err := slicer.ParseOptsStrict(url.Values{"anything": {"x"}, "sort": {"y"}}, nil); err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+	})
+
+	t.Run("Faults for a 400 response", func(t *testing.T) {
+		_, err := slicer.ParseOptsStrict(url.Values{"limit": {"-1"}, "sort": {"a,b"}}, allowed)
+		var errs slicer.ParamErrors
+		if !errors.As(err, &errs) {
+			t.Fatalf("Expected ParamErrors, got %v", err)
+		}
+		if errs.Code() != http.StatusBadRequest {
+			t.Errorf("Expected code 400, got %d", errs.Code())
+		}
+
+		f := errs.Faults()
+		if len(f) != 2 {
+			t.Fatalf("Expected faults for limit and sort, got %v", f)
+		}
+		sortErr, ok := f["sort"].(faults.Error)
+		if !ok || sortErr.Code() != http.StatusBadRequest {
+			t.Fatalf("Expected a 400 fault for sort, got %#v", f["sort"])
+		}
+		if msg := sortErr.Error(); !strings.Contains(msg, "a: unknown field") || !strings.Contains(msg, "b: unknown field") {
+			t.Errorf("Unexpected sort fault: %s", msg)
+		}
+	})
+}
@@ -0,0 +1,77 @@
+package slicer_test
+
+import (
+	"encoding/json"
+	"net/url"
+	"testing"
+
+	"github.com/godev90/slicer"
+)
+
+func TestTypedPage(t *testing.T) {
+	type user struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}
+	users := []user{{1, "Ann"}, {2, "Bob"}, {3, "Cid"}}
+	p := slicer.NewSlicePaginator(users, map[string]string{"id": "id", "name": "name"})
+
+	t.Run("SlicePageOf returns typed items", func(t *testing.T) {
+		page, err := slicer.SlicePageOf(p, slicer.ParseOpts(url.Values{"limit": {"2"}, "sort": {"-id"}}))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(page.Items) != 2 || page.Items[0].Name != "Cid" || page.Total != 3 || !page.HasNext {
+			t.Errorf("Unexpected page: %+v", page)
+		}
+	})
+
+	t.Run("Encodes like PageData", func(t *testing.T) {
+		opts := slicer.ParseOpts(url.Values{"limit": {"2"}})
+		typed, _ := slicer.SlicePageOf(p, opts)
+		untyped, _ := slicer.SlicePage(p, opts)
+
+		a, _ := json.Marshal(typed)
+		b, _ := json.Marshal(untyped)
+		if string(a) != string(b) {
+			t.Errorf("Expected equal JSON:\n %s\n %s", a, b)
+		}
+	})
+
+	t.Run("Error pages have empty items", func(t *testing.T) {
+		strict := slicer.NewSlicePaginator(users, map[string]string{"id": "id"}).
+			Configure(slicer.Config{Limits: slicer.Limits{Mode: slicer.LimitReject, MaxLimit: 1}})
+		page, err := slicer.SlicePageOf(strict, slicer.QueryOptions{Page: 1, Limit: 5})
+		if err == nil || page.Items == nil || len(page.Items) != 0 {
+			t.Errorf("Expected an error page with empty items, got %+v (%v)", page, err)
+		}
+	})
+
+	t.Run("Proto round trip", func(t *testing.T) {
+		page, _ := slicer.SlicePageOf(p, slicer.ParseOpts(url.Values{"limit": {"2"}}))
+
+		msg, err := page.ToProto()
+		if err != nil {
+			t.Fatal(err)
+		}
+		back, err := slicer.PageFromProtoOf[user](msg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(back.Items) != 2 || back.Items[1].Name != "Bob" || back.Total != 3 || back.TotalPages != 2 {
+			t.Errorf("Unexpected page: %+v", back)
+		}
+
+		buf, err := page.ToProtoBuf()
+		if err != nil {
+			t.Fatal(err)
+		}
+		backBuf, err := slicer.PageFromProtoBufOf[user](buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(backBuf.Items) != 2 || backBuf.Items[0].ID != 1 {
+			t.Errorf("Unexpected page: %+v", backBuf)
+		}
+	})
+}
@@ -0,0 +1,134 @@
+package slicer_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/godev90/slicer"
+	"github.com/godev90/validator/typedef"
+)
+
+func TestXLSXWriter(t *testing.T) {
+	type order struct {
+		ID      int              `json:"id"`
+		Name    string           `json:"name"`
+		Amount  typedef.Float    `json:"amount"`
+		Day     typedef.Date     `json:"day"`
+		Created typedef.Datetime `json:"created"`
+		Qty     typedef.Integer  `json:"qty"`
+		Paid    bool             `json:"paid"`
+	}
+	var day typedef.Date
+	day.Set("2024-03-01")
+	var created typedef.Datetime
+	created.Set(time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC))
+
+	orders := []order{
+		{ID: 1, Name: "Ann & <Co>", Amount: typedef.NewFloat(12.5), Day: day, Created: created, Qty: typedef.NewInteger(3), Paid: true},
+		{ID: 2, Name: "Bob"},
+	}
+	allowed := map[string]string{"id": "id", "name": "name", "amount": "amount", "day": "day", "created": "created", "qty": "qty", "paid": "paid"}
+	p := slicer.NewSlicePaginator(orders, allowed)
+	opts := slicer.QueryOptions{Page: 1, Limit: 10, Select: []string{"name", "id", "amount", "day", "created", "qty", "paid"}}
+	data, _ := slicer.SlicePage(p, opts)
+	columns := slicer.ExportColumns(opts, allowed)
+
+	var buf bytes.Buffer
+	ew, err := slicer.NewXLSXWriter(&buf, slicer.XLSXOptions{
+		SheetName:    "Orders",
+		ColumnWidths: map[string]float64{"name": 30},
+		DefaultWidth: 12,
+		DateFormat:   "dd/mm/yyyy",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := slicer.ExportPage(ew, data, columns); err != nil {
+		t.Fatal(err)
+	}
+	if err := ew.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("Invalid archive: %v", err)
+	}
+	parts := map[string]string{}
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, _ := io.ReadAll(rc)
+		rc.Close()
+		if err := xml.Unmarshal(b, new(struct{})); err != nil {
+			t.Errorf("%s is not well-formed: %v", f.Name, err)
+		}
+		parts[f.Name] = string(b)
+	}
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels", "xl/styles.xml", "xl/worksheets/sheet1.xml"} {
+		if _, ok := parts[name]; !ok {
+			t.Errorf("Missing part %s", name)
+		}
+	}
+
+	sheet := parts["xl/worksheets/sheet1.xml"]
+	for _, want := range []string{
+		`<col min="1" max="1" width="30" customWidth="1"/><col min="2" max="2" width="12" customWidth="1"/>`,
+		`<c r="A1" s="3" t="inlineStr"><is><t xml:space="preserve">name</t></is></c>`,
+		`<c r="A2" t="inlineStr"><is><t xml:space="preserve">Ann &amp; &lt;Co&gt;</t></is></c>`,
+		`<c r="B2"><v>1</v></c>`,
+		`<c r="C2"><v>12.5</v></c>`,
+		`<c r="D2" s="1"><v>45352</v></c>`,
+		`<c r="E2" s="2"><v>45352.5</v></c>`,
+		`<c r="F2"><v>3</v></c>`,
+		`<c r="G2" t="b"><v>1</v></c>`,
+		`<row r="3"><c r="A3" t="inlineStr"><is><t xml:space="preserve">Bob</t></is></c><c r="B3"><v>2</v></c><c r="C3"><v>0</v></c><c r="F3"><v>0</v></c><c r="G3" t="b"><v>0</v></c></row>`,
+	} {
+		if !strings.Contains(sheet, want) {
+			t.Errorf("Expected sheet to contain %s\n%s", want, sheet)
+		}
+	}
+	if !strings.Contains(parts["xl/workbook.xml"], `name="Orders"`) {
+		t.Errorf("Expected the sheet to be named Orders")
+	}
+	if !strings.Contains(parts["xl/styles.xml"], `formatCode="dd/mm/yyyy"`) {
+		t.Errorf("Expected the configured date format")
+	}
+
+	t.Run("Export format", func(t *testing.T) {
+		if got, err := slicer.ParseExportFormat("xlsx"); err != nil || got != slicer.ExportXLSX {
+			t.Errorf("Expected xlsx, got %s (%v)", got, err)
+		}
+		if got, _ := slicer.NegotiateExportFormat(slicer.ExportXLSX.ContentType()); got != slicer.ExportXLSX {
+			t.Errorf("Expected xlsx, got %s", got)
+		}
+
+		var empty bytes.Buffer
+		ew, _ := slicer.NewExportWriter(&empty, slicer.ExportXLSX)
+		if err := ew.Close(); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := zip.NewReader(bytes.NewReader(empty.Bytes()), int64(empty.Len())); err != nil {
+			t.Errorf("Expected a valid empty workbook: %v", err)
+		}
+	})
+
+	t.Run("Sheet names", func(t *testing.T) {
+		for _, name := range []string{"Q1/Q2", "[draft]", "a:b", "what?", "all*", `back\slash`, strings.Repeat("x", 32)} {
+			if _, err := slicer.NewXLSXWriter(io.Discard, slicer.XLSXOptions{SheetName: name}); !errors.Is(err, slicer.ErrInvalidSheetName) {
+				t.Errorf("%q: expected ErrInvalidSheetName, got %v", name, err)
+			}
+		}
+		if _, err := slicer.NewXLSXWriter(io.Discard, slicer.XLSXOptions{SheetName: strings.Repeat("é", 31)}); err != nil {
+			t.Errorf("Expected 31 characters to be accepted, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,76 @@
+package slicer
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/godev90/orm"
+	slicerpb "github.com/godev90/slicer/pb"
+)
+
+// TypedPage is PageData with typed items. The embedded PageData carries the
+// metadata and holds the same items as `any`, so page.PageData can be passed
+// on to existing code and the promoted ToProto and ToProtoBuf methods work
+// as before. A TypedPage encodes to the same JSON as its PageData.
+type TypedPage[T any] struct {
+	PageData
+	Items []T `json:"items"`
+}
+
+// MarshalJSON encodes the page exactly like the equivalent PageData.
+func (p TypedPage[T]) MarshalJSON() ([]byte, error) {
+	data := p.PageData
+	data.Items = p.Items
+	return json.Marshal(data)
+}
+
+// typedPage wraps data, whose items are a []T unless it is an error page.
+func typedPage[T any](data PageData) TypedPage[T] {
+	items, _ := data.Items.([]T)
+	if items == nil {
+		items = []T{}
+	}
+	data.Items = items
+	return TypedPage[T]{PageData: data, Items: items}
+}
+
+// QueryPageOf is QueryPage returning typed items.
+func QueryPageOf[T orm.Tabler](paginator Paginator[T], opts QueryOptions) (TypedPage[T], error) {
+	return QueryPageOfContext(context.Background(), paginator, opts)
+}
+
+// QueryPageOfContext is QueryPageContext returning typed items.
+func QueryPageOfContext[T orm.Tabler](ctx context.Context, paginator Paginator[T], opts QueryOptions) (TypedPage[T], error) {
+	data, err := QueryPageContext(ctx, paginator, opts)
+	return typedPage[T](data), err
+}
+
+// SlicePageOf is SlicePage returning typed items.
+func SlicePageOf[T any](p *SlicePaginator[T], opts QueryOptions) (TypedPage[T], error) {
+	data, err := SlicePage(p, opts)
+	return typedPage[T](data), err
+}
+
+// PageFromProtoOf is PageFromProto decoding the items into a []T.
+func PageFromProtoOf[T any](protoData *slicerpb.PageData) (*TypedPage[T], error) {
+	var items []T
+	data, err := PageFromProto(protoData, &items)
+	if err != nil {
+		return nil, err
+	}
+	data.Items = items
+	page := typedPage[T](*data)
+	return &page, nil
+}
+
+// PageFromProtoBufOf is PageFromProtoBuf decoding the items into a []T.
+func PageFromProtoBufOf[T any](protoData *slicerpb.PageDataBuf) (*TypedPage[T], error) {
+	var items []T
+	data, err := PageFromProtoBuf(protoData, &items)
+	if err != nil {
+		return nil, err
+	}
+	data.Items = items
+	page := typedPage[T](*data)
+	return &page, nil
+}
@@ -0,0 +1,325 @@
+package slicer
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/godev90/validator/typedef"
+)
+
+var (
+	ErrInvalidSheetName error = errors.New("slicer: invalid sheet name")
+	ErrXLSXRowLimit     error = errors.New("slicer: xlsx sheet row limit reached")
+)
+
+// XLSXOptions configures NewXLSXWriter. The zero value writes a sheet named
+// "Sheet1" with default column widths and ISO date formats.
+type XLSXOptions struct {
+	// SheetName names the worksheet. Defaults to "Sheet1". Excel accepts
+	// at most 31 characters and none of []:*?/\.
+	SheetName string
+
+	// ColumnWidths sets the width of columns by name, in characters.
+	// Columns not listed get DefaultWidth.
+	ColumnWidths map[string]float64
+
+	// DefaultWidth is the width of columns missing from ColumnWidths. Zero
+	// keeps the spreadsheet default.
+	DefaultWidth float64
+
+	// DateFormat is the Excel number format of date cells. Defaults to
+	// "yyyy-mm-dd".
+	DateFormat string
+
+	// DatetimeFormat is the Excel number format of date-time cells.
+	// Defaults to "yyyy-mm-dd hh:mm:ss".
+	DatetimeFormat string
+}
+
+// Cell styles declared in xlsxStyles.
+const (
+	xlsxStyleDefault = iota
+	xlsxStyleDate
+	xlsxStyleDatetime
+	xlsxStyleHeader
+)
+
+// xlsxMaxRows is the number of rows of an Excel sheet, header included.
+const xlsxMaxRows = 1 << 20
+
+// xlsxMaxSheetName is the longest sheet name Excel accepts, in characters.
+const xlsxMaxSheetName = 31
+
+// xlsxEpoch is day zero of the 1900 date system as Excel counts it.
+var xlsxEpoch = time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+
+// xlsxExport streams a single worksheet into a zip archive. The fixed parts
+// of the package are written on Close, after the sheet.
+type xlsxExport struct {
+	zip     *zip.Writer
+	sheet   *bufio.Writer
+	options XLSXOptions
+	rows    int
+	started bool
+}
+
+// NewXLSXWriter returns an ExportWriter producing an Office Open XML
+// spreadsheet (.xlsx) on w. Rows are streamed into the worksheet as they
+// are written; Close completes the file. Numbers (including
+// typedef.Integer and typedef.Float) become numeric cells, typedef.Date,
+// typedef.Datetime and time.Time become date cells and booleans boolean
+// cells; everything else is written as text. The header row is bold.
+// Sheet names Excel would reject fail with ErrInvalidSheetName, and rows
+// past the 1,048,576 a sheet holds with ErrXLSXRowLimit.
+func NewXLSXWriter(w io.Writer, options XLSXOptions) (ExportWriter, error) {
+	if options.SheetName == "" {
+		options.SheetName = "Sheet1"
+	}
+	if n := len([]rune(options.SheetName)); n > xlsxMaxSheetName || strings.ContainsAny(options.SheetName, `[]:*?/\`) {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidSheetName, options.SheetName)
+	}
+	if options.DateFormat == "" {
+		options.DateFormat = "yyyy-mm-dd"
+	}
+	if options.DatetimeFormat == "" {
+		options.DatetimeFormat = "yyyy-mm-dd hh:mm:ss"
+	}
+
+	e := &xlsxExport{zip: zip.NewWriter(w), options: options}
+	sheet, err := e.zip.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, err
+	}
+	e.sheet = bufio.NewWriter(sheet)
+	return e, nil
+}
+
+func (e *xlsxExport) WriteHeader(columns []string) error {
+	e.started = true
+
+	e.sheet.WriteString(xml.Header)
+	e.sheet.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`)
+	if cols := e.columnWidths(columns); cols != "" {
+		e.sheet.WriteString(cols)
+	}
+	e.sheet.WriteString(`<sheetData>`)
+
+	values := make([]any, len(columns))
+	for i, column := range columns {
+		values[i] = column
+	}
+	return e.writeRow(values, xlsxStyleHeader)
+}
+
+func (e *xlsxExport) WriteRow(values []any) error {
+	return e.writeRow(values, xlsxStyleDefault)
+}
+
+func (e *xlsxExport) Close() error {
+	if !e.started {
+		if err := e.WriteHeader(nil); err != nil {
+			return err
+		}
+	}
+	e.sheet.WriteString(`</sheetData></worksheet>`)
+	if err := e.sheet.Flush(); err != nil {
+		return err
+	}
+
+	var sheetName strings.Builder
+	xml.EscapeText(&sheetName, []byte(e.options.SheetName))
+
+	parts := []struct{ name, content string }{
+		{"[Content_Types].xml", xlsxContentTypes},
+		{"_rels/.rels", xlsxRootRels},
+		{"xl/workbook.xml", fmt.Sprintf(xlsxWorkbook, sheetName.String())},
+		{"xl/_rels/workbook.xml.rels", xlsxWorkbookRels},
+		{"xl/styles.xml", e.styles()},
+	}
+	for _, part := range parts {
+		f, err := e.zip.Create(part.name)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(f, part.content); err != nil {
+			return err
+		}
+	}
+	return e.zip.Close()
+}
+
+// writeRow appends a <row> element with one cell per value.
+func (e *xlsxExport) writeRow(values []any, style int) error {
+	if e.rows == xlsxMaxRows {
+		return fmt.Errorf("%w: %d rows", ErrXLSXRowLimit, xlsxMaxRows)
+	}
+	e.rows++
+	fmt.Fprintf(e.sheet, `<row r="%d">`, e.rows)
+	for i, v := range values {
+		e.writeCell(xlsxCellRef(i, e.rows), v, style)
+	}
+	_, err := e.sheet.WriteString(`</row>`)
+	return err
+}
+
+// writeCell writes a single typed cell. Nil values leave the cell empty.
+func (e *xlsxExport) writeCell(ref string, v any, style int) {
+	number := func(n string, style int) {
+		if style != xlsxStyleDefault {
+			fmt.Fprintf(e.sheet, `<c r="%s" s="%d"><v>%s</v></c>`, ref, style, n)
+		} else {
+			fmt.Fprintf(e.sheet, `<c r="%s"><v>%s</v></c>`, ref, n)
+		}
+	}
+	date := func(t time.Time, style int) {
+		number(strconv.FormatFloat(xlsxSerial(t), 'f', -1, 64), style)
+	}
+
+	switch value := v.(type) {
+	case nil:
+		return
+	case typedef.Integer:
+		if value.Valid() {
+			number(strconv.FormatInt(value.Int64(), 10), style)
+		}
+		return
+	case typedef.Float:
+		if value.Valid() && !math.IsNaN(value.Float64()) && !math.IsInf(value.Float64(), 0) {
+			number(strconv.FormatFloat(value.Float64(), 'f', -1, 64), style)
+		}
+		return
+	case typedef.Date:
+		if value.Valid() && !value.IsZero() {
+			date(value.Time(), xlsxStyleDate)
+		}
+		return
+	case typedef.Datetime:
+		if value.Valid() && !value.IsZero() {
+			date(value.Time(), xlsxStyleDatetime)
+		}
+		return
+	case time.Time:
+		if !value.IsZero() {
+			date(value, xlsxStyleDatetime)
+		}
+		return
+	case bool:
+		b := "0"
+		if value {
+			b = "1"
+		}
+		fmt.Fprintf(e.sheet, `<c r="%s" t="b"><v>%s</v></c>`, ref, b)
+		return
+	}
+
+	switch rv := reflect.ValueOf(v); rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		number(strconv.FormatInt(rv.Int(), 10), style)
+		return
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		number(strconv.FormatUint(rv.Uint(), 10), style)
+		return
+	case reflect.Float32, reflect.Float64:
+		if f := rv.Float(); !math.IsNaN(f) && !math.IsInf(f, 0) {
+			number(strconv.FormatFloat(f, 'f', -1, 64), style)
+		}
+		return
+	}
+
+	if style != xlsxStyleDefault {
+		fmt.Fprintf(e.sheet, `<c r="%s" s="%d" t="inlineStr"><is><t xml:space="preserve">`, ref, style)
+	} else {
+		fmt.Fprintf(e.sheet, `<c r="%s" t="inlineStr"><is><t xml:space="preserve">`, ref)
+	}
+	xml.EscapeText(e.sheet, []byte(exportText(v)))
+	e.sheet.WriteString(`</t></is></c>`)
+}
+
+// columnWidths renders the <cols> element, or "" when no width is set.
+func (e *xlsxExport) columnWidths(columns []string) string {
+	var b strings.Builder
+	for i, column := range columns {
+		width, ok := e.options.ColumnWidths[column]
+		if !ok {
+			width = e.options.DefaultWidth
+		}
+		if width > 0 {
+			fmt.Fprintf(&b, `<col min="%d" max="%d" width="%s" customWidth="1"/>`, i+1, i+1, strconv.FormatFloat(width, 'f', -1, 64))
+		}
+	}
+	if b.Len() == 0 {
+		return ""
+	}
+	return "<cols>" + b.String() + "</cols>"
+}
+
+// styles renders xl/styles.xml with the configured date formats.
+func (e *xlsxExport) styles() string {
+	var date, datetime strings.Builder
+	xml.EscapeText(&date, []byte(e.options.DateFormat))
+	xml.EscapeText(&datetime, []byte(e.options.DatetimeFormat))
+	return fmt.Sprintf(xlsxStyles, date.String(), datetime.String())
+}
+
+// xlsxCellRef returns the A1-style reference of a zero-based column and a
+// one-based row.
+func xlsxCellRef(col, row int) string {
+	name := ""
+	for col++; col > 0; col = (col - 1) / 26 {
+		name = string(rune('A'+(col-1)%26)) + name
+	}
+	return name + strconv.Itoa(row)
+}
+
+// xlsxSerial converts t to an Excel serial date, keeping its wall clock.
+func xlsxSerial(t time.Time) float64 {
+	wall := time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+	return wall.Sub(xlsxEpoch).Seconds() / 86400
+}
+
+const xlsxContentTypes = xml.Header + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+	`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+	`<Default Extension="xml" ContentType="application/xml"/>` +
+	`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+	`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+	`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>` +
+	`</Types>`
+
+const xlsxRootRels = xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+	`</Relationships>`
+
+const xlsxWorkbook = xml.Header + `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" ` +
+	`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+	`<sheets><sheet name="%s" sheetId="1" r:id="rId1"/></sheets></workbook>`
+
+const xlsxWorkbookRels = xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+	`<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>` +
+	`</Relationships>`
+
+// xlsxStyles declares the cell formats in the order of the xlsxStyle
+// constants: default, date, date-time and bold header.
+const xlsxStyles = xml.Header + `<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+	`<numFmts count="2"><numFmt numFmtId="164" formatCode="%s"/><numFmt numFmtId="165" formatCode="%s"/></numFmts>` +
+	`<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>` +
+	`<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>` +
+	`<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>` +
+	`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>` +
+	`<cellXfs count="4">` +
+	`<xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/>` +
+	`<xf numFmtId="164" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>` +
+	`<xf numFmtId="165" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>` +
+	`<xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/>` +
+	`</cellXfs>` +
+	`<cellStyles count="1"><cellStyle name="Normal" xfId="0" builtinId="0"/></cellStyles>` +
+	`</styleSheet>`
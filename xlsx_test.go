@@ -0,0 +1,26 @@
+package slicer
+
+import (
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestXLSXRowLimit(t *testing.T) {
+	ew, err := NewXLSXWriter(io.Discard, XLSXOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ew.WriteHeader([]string{"id"}); err != nil {
+		t.Fatal(err)
+	}
+
+	// skip ahead to the last row of the sheet
+	ew.(*xlsxExport).rows = xlsxMaxRows - 1
+	if err := ew.WriteRow([]any{1}); err != nil {
+		t.Fatalf("Expected the last row to fit, got %v", err)
+	}
+	if err := ew.WriteRow([]any{2}); !errors.Is(err, ErrXLSXRowLimit) {
+		t.Errorf("Expected ErrXLSXRowLimit, got %v", err)
+	}
+}